@@ -0,0 +1,9 @@
+// the OpenAPI document of the versioned HTTP API. client SDKs are generated from it
+package api
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document of the /v1 API, in YAML
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
@@ -1,22 +1,138 @@
 package controllers
 
 import (
+	"bytes"
 	"encoding/json"
-	"io"
+	"fmt"
 	"math"
 	"net/http"
+	"slices"
+	"sort"
+	"time"
 
+	"github.com/aries-financial-inc/options-service/costs"
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/margin"
 	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/aries-financial-inc/options-service/strategies"
 )
 
+// AnalysisRequest represents the data structure of a request for analysis
+type AnalysisRequest struct {
+	Contracts []options.OptionsContract `json:"contracts"`
+	// optional. the implied volatilities are calculated when the market is given, and the greeks when its volatility is given too
+	Market *pricing.Market `json:"market,omitempty"`
+	// the time at which the contracts are valued. defaults to now
+	ValuationDate time.Time `json:"valuation_date"`
+	// optional. more pre-expiry curves, between the valuation date and the nearest expiration date
+	EvaluationDates []time.Time `json:"evaluation_dates,omitempty"`
+	// optional. the profits and losses are net of the costs, and the options without a fill price fill at the prices of its fill assumption
+	Costs *costs.Model `json:"costs,omitempty"`
+	// optional. the current underlying price, when the market is not given. it is the spot price of the market otherwise
+	Spot float64 `json:"spot,omitempty"`
+	// optional. the underlying prices of the xy values and the curves
+	Range *Range `json:"range,omitempty"`
+}
+
+// a bare array of contracts is a request without market
+func (r *AnalysisRequest) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		*r = AnalysisRequest{}
+		return json.Unmarshal(data, &r.Contracts)
+	}
+
+	// the alias drops the UnmarshalJSON method. unmarshaling into the request itself recurses forever
+	type analysisRequest AnalysisRequest
+	return json.Unmarshal(data, (*analysisRequest)(r))
+}
+
+// all errors of the request are returned, by leg and field
+func (r AnalysisRequest) IsValid() error {
+	errs := appErrors.ValidationErrors{}
+	errs.Add("", validateContracts(r.Contracts))
+
+	if r.Market != nil {
+		errs.Add("market", validateMarket(*r.Market, r.Contracts, r.ValuationDate))
+	}
+
+	if r.Costs != nil {
+		errs.Add("costs", r.Costs.IsValid(r.Contracts))
+	}
+
+	// zero is no spot price
+	if !(r.Spot >= 0 && r.Spot <= pricing.MaxSpot) {
+		errs.Add("spot", fmt.Errorf("%w: expected 0 to %g", appErrors.ErrInvalidSpotPrice, float64(pricing.MaxSpot)))
+	} else if r.Spot > 0 && r.Market != nil && r.Market.Spot != r.Spot {
+		errs.Add("spot", fmt.Errorf("%w: the spot price differs from the spot price of the market", appErrors.ErrInvalidSpotPrice))
+	}
+
+	if r.Range != nil {
+		errs.Add("range", r.Range.IsValid(r.spot(), r.Market))
+	}
+
+	// the options expiring later are valued with the pricing model, when the first ones expire
+	if hasMixedExpirations(r.Contracts) && (r.Market == nil || r.Market.Volatility == 0) {
+		errs.Add("market.volatility", fmt.Errorf("%w: mixed expiration dates need a volatility", appErrors.ErrInvalidVolatility))
+	}
+
+	errs.Add("", r.validateEvaluationDates())
+	return errs.Err()
+}
+
 // AnalysisResponse represents the data structure of the analysis result
-// TODO: group XY values for each option
+// when the options expire at different dates, the payoff is at the nearest expiration date
 type AnalysisResponse struct {
-	// for different underlying values for each option at expiry and their profits/ losses
+	// for different underlying values at expiry and the combined profits/ losses of all options
 	XYValues        []XYValue `json:"xy_values"`
-	MaxProfit       float64   `json:"max_profit"`
-	MaxLoss         float64   `json:"max_loss"`
+	MaxProfit       Extremum  `json:"max_profit"`
+	MaxLoss         Extremum  `json:"max_loss"`
 	BreakEvenPoints []float64 `json:"break_even_points"`
+	Strategy        Strategy  `json:"strategy"`
+	// only when the costs are given. the other figures are net of the total
+	Costs *costs.Costs `json:"costs,omitempty"`
+	// estimate of the initial margin. only when the spot price or the market is given, unless there are no naked options and no stock
+	Margin  *margin.Margin `json:"margin,omitempty"`
+	Metrics Metrics        `json:"metrics"`
+	// only when the market and its volatility are given
+	Greeks *GreeksResult `json:"greeks,omitempty"`
+	// only when the market is given. in the order of the contracts
+	ImpliedVolatilities []ImpliedVolatility `json:"implied_volatilities,omitempty"`
+	// only when the market and its volatility are given. profits/ losses before expiry
+	Curves []Curve `json:"curves,omitempty"`
+	// only when the market is given, with a volatility or implied volatilities, and options expire
+	Probabilities *Probabilities `json:"probabilities,omitempty"`
+}
+
+// Strategy represents the recognized strategy of the contracts, custom when it is not recognized.
+// the risk is defined when the loss is limited
+type Strategy struct {
+	Name string `json:"name"`
+	Risk Risk   `json:"risk"`
+}
+
+type Risk string
+
+const (
+	DEFINED_RISK   Risk = "defined"
+	UNDEFINED_RISK Risk = "undefined"
+)
+
+// GreeksResult represents the greeks of each contract, in the order of the contracts, and of the whole position.
+// they are scaled by the number of shares and the direction of the contracts
+type GreeksResult struct {
+	Contracts []pricing.Greeks `json:"contracts"`
+	Position  pricing.Greeks   `json:"position"`
+}
+
+// ImpliedVolatility represents the implied volatilities of a contract from its mid, bid and ask prices.
+// they are null when there is no solution, e.g. for a price below the intrinsic value
+type ImpliedVolatility struct {
+	Mid *float64 `json:"mid"`
+	Bid *float64 `json:"bid"`
+	Ask *float64 `json:"ask"`
+	// the reason there is no solution for the mid price
+	Error string `json:"error,omitempty"`
 }
 
 // XYValue represents a pair of X and Y values
@@ -25,106 +141,337 @@ type XYValue struct {
 	Y float64 `json:"y"` // is the profit or loss at that price
 }
 
+// Extremum represents the maximum profit or the maximum loss, and the underlying price at expiry where it is reached
+type Extremum struct {
+	Value float64
+	Price float64
+	// the payoff keeps growing or falling with the underlying price. value and price are meaningless
+	Unlimited bool
+}
+
+// a finite extremum is {"value": -28.04, "price": 100}. an unlimited extremum is {"value": "unlimited"}
+func (e Extremum) MarshalJSON() ([]byte, error) {
+	if e.Unlimited {
+		return json.Marshal(struct {
+			Value string `json:"value"`
+		}{"unlimited"})
+	}
+
+	return json.Marshal(struct {
+		Value float64 `json:"value"`
+		Price float64 `json:"price"`
+	}{e.Value, e.Price})
+}
+
+func (e *Extremum) UnmarshalJSON(data []byte) error {
+	extremum := struct {
+		Value json.RawMessage `json:"value"`
+		Price float64         `json:"price"`
+	}{}
+	if err := json.Unmarshal(data, &extremum); err != nil {
+		return err
+	}
+
+	if string(extremum.Value) == `"unlimited"` {
+		*e = Extremum{Unlimited: true}
+		return nil
+	}
+
+	*e = Extremum{Price: extremum.Price}
+	return json.Unmarshal(extremum.Value, &e.Value)
+}
+
+// MaxOptionsContracts is the maximum number of options contracts accepted for analysis. it is configured at startup
+var MaxOptionsContracts = 4
+
 // TODO: add logging for all failures
 func AnalysisHandler(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	req := AnalysisRequest{}
+	if err := readRequest(r, &req); err != nil {
+		WriteError(w, err)
 		return
 	}
 
-	options := []options.OptionsContract{}
-	err = json.Unmarshal(body, &options)
+	writeAnalysis(w, req, func(resp AnalysisResponse) any { return resp })
+}
+
+// validates the request and writes its analysis, wrapped in a response by the caller
+func writeAnalysis(w http.ResponseWriter, req AnalysisRequest, wrap func(AnalysisResponse) any) {
+	resp, err := AnalyzeRequest(req)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		WriteError(w, err)
 		return
 	}
 
-	if len(options) != 4 {
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	writeResponse(w, wrap(resp))
+}
+
+// AnalyzeRequest validates the request and returns its analysis. the valuation date defaults to now.
+// it is the analysis of every API, HTTP or gRPC
+func AnalyzeRequest(req AnalysisRequest) (AnalysisResponse, error) {
+	if req.ValuationDate.IsZero() {
+		req.ValuationDate = time.Now()
 	}
 
-	for _, o := range options {
-		if err := o.IsValid(); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
+	if err := req.IsValid(); err != nil {
+		return AnalysisResponse{}, appErrors.InvalidRequest(err)
+	}
+	return Analyze(req), nil
+}
+
+// Analyze returns the analysis of a valid request
+// TODO: fix repeated computations of X and Y values
+func Analyze(req AnalysisRequest) AnalysisResponse {
+	var positionCosts *costs.Costs
+	if req.Costs != nil {
+		req.Contracts = req.Costs.Filled(req.Contracts)
+		c := req.Costs.Calculate(req.Contracts)
+		positionCosts = &c
+	}
+
+	// the payoff, the probabilities and the curves value the American legs many times. their trees stay within the node budget
+	var analysisMarket pricing.Market
+	if req.Market != nil {
+		analysisMarket = *req.Market
+		analysisMarket.BinomialSteps = req.binomialSteps()
+	}
+
+	p := expiryPayoff(req.Contracts)
+	if hasMixedExpirations(req.Contracts) {
+		p = nearestExpiryPayoff(req.Contracts, analysisMarket)
+	}
+	if positionCosts != nil {
+		p = p.lessCosts(positionCosts.Total)
 	}
 
-	// TODO: fix repeated computations of X and Y values
 	resp := AnalysisResponse{
-		XYValues:        CalculateXYValues(options),
-		MaxProfit:       CalculateMaxProfit(options),
-		MaxLoss:         CalculateMaxLoss(options),
-		BreakEvenPoints: CalculateBreakEvenPoints(options),
+		XYValues:        p.xyValues(),
+		MaxProfit:       p.maxProfit(),
+		MaxLoss:         p.maxLoss(),
+		BreakEvenPoints: p.breakEvenPoints(),
 	}
+	resp.Strategy = RecognizeStrategy(req.Contracts, resp.MaxLoss)
+	resp.Costs = positionCosts
 
-	res, err := json.Marshal(resp)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	spot := req.spot()
+	if m, err := margin.Calculate(req.Contracts, spot); err == nil {
+		resp.Margin = &m
+	}
+	resp.Metrics = calculateMetrics(req.Contracts, resp, positionCosts, resp.Margin, spot)
+
+	var distribution *pricing.Lognormal
+	if req.Market != nil {
+		resp.ImpliedVolatilities = CalculateImpliedVolatilities(req.Contracts, *req.Market, req.ValuationDate)
+		if d, ok := expiryDistribution(req.Contracts, *req.Market, req.ValuationDate, resp.ImpliedVolatilities); ok {
+			probabilities := calculateProbabilities(p, resp.BreakEvenPoints, resp.MaxProfit, d)
+			resp.Probabilities = &probabilities
+			distribution = &d
+		}
 	}
-	w.Write(res)
+
+	curvePrices := curveGrid(req.Contracts)
+	if req.Range != nil {
+		curvePrices = req.Range.grid(req.Contracts, spot, distribution)
+		xMin, xMax := curvePrices[0], curvePrices[len(curvePrices)-1]
+		resp.XYValues = p.xyValuesAt(append(curvePrices, between(resp.BreakEvenPoints, xMin, xMax)...))
+	}
+
+	if req.Market != nil && req.Market.Volatility > 0 {
+		greeks := CalculateGreeks(req.Contracts, *req.Market, req.ValuationDate)
+		resp.Greeks = &greeks
+		resp.Curves = calculateCurves(req.Contracts, analysisMarket, req.ValuationDate, req.EvaluationDates, curvePrices)
+		if positionCosts != nil {
+			lessCosts(resp.Curves, positionCosts.Total)
+		}
+	}
+	return resp
+}
+
+// MaxAnalysisNodes is the budget of binomial tree nodes for the American legs of an analysis, over all their valuations. it is configured at startup
+var MaxAnalysisNodes = 10_000_000
+
+// the fewest steps of the binomial trees of an analysis, whatever the budget
+const minAnalysisSteps = 20
+
+// estimated valuations of the payoff at each of its prices, for the extremes and the break even points
+const payoffValuations = 10
+
+// steps of the binomial trees of the American legs, so that the valuations of the analysis stay within MaxAnalysisNodes.
+// the payoff is valued a few times at each of its prices and once per interval of the expectation, and each curve once per curve price.
+// the greeks are valued with BinomialSteps
+func (r AnalysisRequest) binomialSteps() int {
+	valuations := r.binomialValuations()
+	if valuations == 0 {
+		return pricing.BinomialSteps
+	}
+
+	// a tree of n steps has (n+1)(n+2)/2 nodes
+	steps := int(math.Sqrt(2 * float64(MaxAnalysisNodes) / float64(valuations)))
+	return min(pricing.BinomialSteps, max(steps, minAnalysisSteps))
+}
+
+// estimated binomial tree nodes of the analysis. within MaxAnalysisNodes, unless the trees have their fewest steps
+func (r AnalysisRequest) binomialNodes() int {
+	steps := r.binomialSteps()
+	return r.binomialValuations() * (steps + 1) * (steps + 2) / 2
+}
+
+// estimated valuations of the American legs of the analysis. zero without American legs
+func (r AnalysisRequest) binomialValuations() int {
+	american := 0
+	for _, c := range r.Contracts {
+		if c.OptionsType.Value() != options.STOCK && c.ExerciseStyle.Value() == options.AMERICAN {
+			american++
+		}
+	}
+	if american == 0 {
+		return 0
+	}
+
+	curvePoints := len(curveGrid(r.Contracts))
+	if r.Range != nil {
+		curvePoints = DefaultRangePoints
+		if r.Range.Points > 0 {
+			curvePoints = r.Range.Points
+		}
+	}
+	return american * ((2+len(r.EvaluationDates))*curvePoints + pricing.ExpectationIntervals + payoffValuations*len(curveGrid(r.Contracts)))
+}
+
+// the spot price of the request or of its market. zero when it is not known
+func (r AnalysisRequest) spot() float64 {
+	if r.Market != nil {
+		return r.Market.Spot
+	}
+	return r.Spot
+}
+
+// the market is valid, and so are its dividends until the contracts expire
+func validateMarket(m pricing.Market, contracts []options.OptionsContract, now time.Time) error {
+	if err := m.IsValid(); err != nil {
+		return err
+	}
+	return m.ValidateDividends(contracts, now)
+}
+
+// a request carries 1 to MaxOptionsContracts valid contracts. all errors are returned, by leg and field
+func validateContracts(contracts []options.OptionsContract) error {
+	errs := appErrors.ValidationErrors{}
+	if len(contracts) < 1 || len(contracts) > MaxOptionsContracts {
+		errs.Add("contracts", fmt.Errorf("%w: got %d, expected 1 to %d", appErrors.ErrInvalidNumberOfContracts, len(contracts), MaxOptionsContracts))
+	}
+
+	for i, c := range contracts {
+		errs.AddLeg(i, c.Validate().Err())
+	}
+	return errs.Err()
 }
 
 // for a option, the range of X is (0, 2 * strike price)
 // the range of X for the graph is the (0, maximum of 2 * strike price), for all options
-// for boundary X values, calculate profits or losses for all options.  this enables comparision of options' profits and losses for a given price
-// the values of X are min X, max X (boundaries of X range), all strike prices and all break even points
+// the payoff of every option is linear between strike prices. so the combined payoff of all options is piecewise linear, with kinks at the strike prices
+// stock legs are linear everywhere. their entry prices are used in place of strike prices
+// the values of X are min X, max X (boundaries of X range), all strike prices and all break even points. the values of Y are the combined profits or losses of all options at X
 func CalculateXYValues(contracts []options.OptionsContract) []XYValue {
-	xMin := 0.0
-	xMax := 0.0
+	return expiryPayoff(contracts).xyValues()
+}
 
-	xyValues := []XYValue{}
+// CalculateProfitOrLoss returns the combined profit or loss of all options at the given underlying price at expiry
+func CalculateProfitOrLoss(contracts []options.OptionsContract, price float64) float64 {
+	profitOrLoss := 0.0
 	for _, c := range contracts {
-		xyValues = append(xyValues, XYValue{c.StrikePrice, c.CalculateProfitOrLoss(c.StrikePrice)})
-		xyValues = append(xyValues, XYValue{c.CalculateBreakEvenPoint(), c.CalculateProfitOrLoss(c.CalculateBreakEvenPoint())})
-		if xMax < 2*c.StrikePrice {
-			xMax = 2 * c.StrikePrice
-		}
+		profitOrLoss += c.CalculateProfitOrLoss(price)
 	}
+	return roundToCents(profitOrLoss)
+}
 
-	// calculate Y values for all min and max X values
+// sorted and deduplicated underlying prices at which the combined payoff is sampled
+func priceGrid(contracts []options.OptionsContract) []float64 {
+	xMin := 0.0
+	xMax := 0.0
+
+	prices := []float64{xMin}
 	for _, c := range contracts {
-		xyValues = append(xyValues, XYValue{xMin, c.CalculateProfitOrLoss(xMin)})
-		xyValues = append(xyValues, XYValue{xMax, c.CalculateProfitOrLoss(xMax)})
+		prices = append(prices, c.ReferencePrice())
+		if xMax < 2*c.ReferencePrice() {
+			xMax = 2 * c.ReferencePrice()
+		}
 	}
+	prices = append(prices, xMax)
 
-	return xyValues
+	sort.Float64s(prices)
+	return slices.Compact(prices)
 }
 
 // return maximum of profits for all options in the underlying price range at expiry
-func CalculateMaxProfit(contracts []options.OptionsContract) float64 {
-	maxProfit := 0.0
-	profitLosses := CalculateXYValues(contracts)
+// the payoff is linear between strike prices. so the maximum is at one of the strike prices, or unlimited if the payoff rises beyond the maximum X
+func CalculateMaxProfit(contracts []options.OptionsContract) Extremum {
+	return expiryPayoff(contracts).maxProfit()
+}
 
-	for _, pl := range profitLosses {
-		if pl.Y > maxProfit {
-			maxProfit = pl.Y
-		}
+// return maximum of losses for all options in the underlying price range at expiry
+// the payoff is linear between strike prices. so the maximum is at one of the strike prices, or unlimited if the payoff falls beyond the maximum X
+func CalculateMaxLoss(contracts []options.OptionsContract) Extremum {
+	return expiryPayoff(contracts).maxLoss()
+}
+
+// break even points are the underlying prices at expiry where the combined payoff of all options crosses zero.
+// the combined payoff is linear between consecutive prices of the grid and beyond the maximum X.
+// so the zero crossings are found exactly by linear interpolation, and by extrapolating the slope of the payoff beyond the maximum X
+func CalculateBreakEvenPoints(contracts []options.OptionsContract) []float64 {
+	return expiryPayoff(contracts).breakEvenPoints()
+}
+
+// names the strategy of the contracts. its risk follows from the maximum loss
+func RecognizeStrategy(contracts []options.OptionsContract, maxLoss Extremum) Strategy {
+	strategy := Strategy{Name: strategies.Recognize(contracts), Risk: DEFINED_RISK}
+	if maxLoss.Unlimited {
+		strategy.Risk = UNDEFINED_RISK
 	}
-	return maxProfit
+	return strategy
 }
 
-// return maximum of losses for all options in the underlying price range at expiry
-func CalculateMaxLoss(contracts []options.OptionsContract) float64 {
-	maxLoss := math.MaxFloat64
-	profitLosses := CalculateXYValues(contracts)
+// greeks of each contract and their sum
+func CalculateGreeks(contracts []options.OptionsContract, market pricing.Market, now time.Time) GreeksResult {
+	greeks := GreeksResult{Contracts: []pricing.Greeks{}}
+	for _, c := range contracts {
+		g := pricing.TheoreticalGreeks(c, market, now).Scale(c.LongShort.Sign() * float64(c.Shares()))
+		greeks.Contracts = append(greeks.Contracts, g)
+		greeks.Position = greeks.Position.Add(g)
+	}
+	return greeks
+}
 
-	for _, pl := range profitLosses {
-		if pl.Y < maxLoss {
-			maxLoss = pl.Y
+// implied volatilities of each contract. stock legs have none
+func CalculateImpliedVolatilities(contracts []options.OptionsContract, market pricing.Market, now time.Time) []ImpliedVolatility {
+	solve := func(c options.OptionsContract, price float64) (*float64, error) {
+		volatility, err := pricing.ImpliedVolatility(c, price, market, now)
+		if err != nil {
+			return nil, err
 		}
+		return &volatility, nil
 	}
-	return maxLoss
-}
 
-// break even points for each option
-func CalculateBreakEvenPoints(contracts []options.OptionsContract) []float64 {
-	breakEvens := []float64{}
+	volatilities := []ImpliedVolatility{}
 	for _, c := range contracts {
-		breakEvens = append(breakEvens, c.CalculateBreakEvenPoint())
+		if c.OptionsType.Value() == options.STOCK {
+			volatilities = append(volatilities, ImpliedVolatility{})
+			continue
+		}
+
+		v := ImpliedVolatility{}
+		var err error
+		if v.Mid, err = solve(c, (c.Bid+c.Ask)/2); err != nil {
+			v.Error = err.Error()
+		}
+		v.Bid, _ = solve(c, c.Bid)
+		v.Ask, _ = solve(c, c.Ask)
+		volatilities = append(volatilities, v)
 	}
-	return breakEvens
+	return volatilities
+}
+
+// sums of prices rounded to two decimal places accumulate floating point errors. round them off again
+func roundToCents(f float64) float64 {
+	return math.Round(f*100) / 100
 }
@@ -1,79 +1,105 @@
 package controllers_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aries-financial-inc/options-service/controllers"
 	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
 	"github.com/stretchr/testify/assert"
 )
 
 // TODO: mock options contract to fine tune unit tests
 func TestCalculateXY(t *testing.T) {
-	assert.ElementsMatch(t, []controllers.XYValue{
+	// combined payoff of all options, at the boundaries of X range and at all strike prices
+	assert.Equal(t, []controllers.XYValue{
 		{
-			X: 100,
-			Y: -12.04,
+			X: 0,
+			Y: -2804,
 		},
 		{
-			X: 112.04,
-			Y: 0,
+			X: 100,
+			Y: -2804,
 		},
 		{
 			X: 102.50,
-			Y: -14,
-		},
-		{
-			X: 116.5,
-			Y: 0,
+			Y: -2554,
 		},
 		{
 			X: 103,
-			Y: 14,
-		},
-		{
-			X: 89,
-			Y: 0,
+			Y: -2454,
 		},
 		{
 			X: 105,
-			Y: -18,
+			Y: -2254,
 		},
 		{
-			X: 87,
+			X: 116.27,
 			Y: 0,
 		},
 		{
-			X: 0,
-			Y: -12.04,
+			X: 210,
+			Y: 18746,
 		},
+	}, controllers.CalculateXYValues([]options.OptionsContract{
 		{
-			X: 210,
-			Y: 97.96,
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   "long",
 		},
 		{
-			X: 0,
-			Y: -14,
+			StrikePrice: 102.50,
+			OptionsType: "Call",
+			Bid:         12.10,
+			Ask:         14,
+			LongShort:   "long",
 		},
 		{
-			X: 210,
-			Y: 93.50,
+			StrikePrice: 103,
+			OptionsType: "Put",
+			Bid:         14,
+			Ask:         15.50,
+			LongShort:   "short",
+		},
+		{
+			StrikePrice: 105,
+			OptionsType: "Put",
+			Bid:         16,
+			Ask:         18,
+			LongShort:   "long",
 		},
+	},
+	))
+}
+
+func TestCalculateXYDeduplicatesStrikePrices(t *testing.T) {
+	// bull call spread, plus a long and a short call at the same strike price that cost the bid ask spread.
+	// payoffs are summed at each X
+	assert.Equal(t, []controllers.XYValue{
 		{
 			X: 0,
-			Y: -89,
+			Y: -284,
 		},
 		{
-			X: 210,
-			Y: 14,
+			X: 100,
+			Y: -284,
 		},
 		{
-			X: 0,
-			Y: 87,
+			X: 102.50,
+			Y: -34,
 		},
 		{
-			X: 210,
-			Y: -18,
+			X: 205,
+			Y: -34,
 		},
 	}, controllers.CalculateXYValues([]options.OptionsContract{
 		{
@@ -86,31 +112,30 @@ func TestCalculateXY(t *testing.T) {
 		{
 			StrikePrice: 102.50,
 			OptionsType: "Call",
-			Bid:         12.10,
-			Ask:         14,
-			LongShort:   "long",
+			Bid:         10.10,
+			Ask:         11,
+			LongShort:   "short",
 		},
 		{
-			StrikePrice: 103,
-			OptionsType: "Put",
-			Bid:         14,
-			Ask:         15.50,
+			StrikePrice: 102.50,
+			OptionsType: "Call",
+			Bid:         10.10,
+			Ask:         11,
 			LongShort:   "short",
 		},
 		{
-			StrikePrice: 105,
-			OptionsType: "Put",
-			Bid:         16,
-			Ask:         18,
+			StrikePrice: 102.50,
+			OptionsType: "Call",
+			Bid:         10.10,
+			Ask:         11,
 			LongShort:   "long",
 		},
 	},
 	))
 }
 
-
 func TestCalculateMaxProfit(t *testing.T) {
-	assert.Equal(t, 97.96, controllers.CalculateMaxProfit([]options.OptionsContract{
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, controllers.CalculateMaxProfit([]options.OptionsContract{
 		{
 			StrikePrice: 100,
 			OptionsType: "Call",
@@ -144,7 +169,7 @@ func TestCalculateMaxProfit(t *testing.T) {
 }
 
 func TestCalculateMaxLoss(t *testing.T) {
-	assert.Equal(t, -89.0, controllers.CalculateMaxLoss([]options.OptionsContract{
+	assert.Equal(t, controllers.Extremum{Value: -2804, Price: 0}, controllers.CalculateMaxLoss([]options.OptionsContract{
 		{
 			StrikePrice: 100,
 			OptionsType: "Call",
@@ -177,8 +202,199 @@ func TestCalculateMaxLoss(t *testing.T) {
 	))
 }
 
-func TestCalculateBreakEvenPoints(t * testing.T){
-	assert.ElementsMatch(t, []float64{112.04, 116.5, 89.0, 87.0}, controllers.CalculateBreakEvenPoints([]options.OptionsContract{
+func TestCalculateMaxProfitAndLossOfNakedShortCall(t *testing.T) {
+	contracts := []options.OptionsContract{
+		{
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   "short",
+		},
+	}
+
+	assert.Equal(t, controllers.Extremum{Value: 1005, Price: 0}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, controllers.CalculateMaxLoss(contracts))
+}
+
+func TestRecognizeStrategy(t *testing.T) {
+	nakedCall := []options.OptionsContract{
+		{StrikePrice: 100, OptionsType: "Call", Bid: 10.05, Ask: 12.04, LongShort: "short"},
+	}
+	assert.Equal(t, controllers.Strategy{Name: "short-call", Risk: controllers.UNDEFINED_RISK},
+		controllers.RecognizeStrategy(nakedCall, controllers.CalculateMaxLoss(nakedCall)))
+
+	spread := append(nakedCall, options.OptionsContract{StrikePrice: 110, OptionsType: "Call", Bid: 5.1, Ask: 5.3, LongShort: "long"})
+	assert.Equal(t, controllers.Strategy{Name: "bear-call-spread", Risk: controllers.DEFINED_RISK},
+		controllers.RecognizeStrategy(spread, controllers.CalculateMaxLoss(spread)))
+}
+
+func TestCalculateMaxProfitAndLossOfIronCondor(t *testing.T) {
+	contracts := []options.OptionsContract{
+		{
+			StrikePrice: 90,
+			OptionsType: "Put",
+			Bid:         0.5,
+			Ask:         1,
+			LongShort:   "long",
+		},
+		{
+			StrikePrice: 95,
+			OptionsType: "Put",
+			Bid:         2,
+			Ask:         2.5,
+			LongShort:   "short",
+		},
+		{
+			StrikePrice: 105,
+			OptionsType: "Call",
+			Bid:         2,
+			Ask:         2.5,
+			LongShort:   "short",
+		},
+		{
+			StrikePrice: 110,
+			OptionsType: "Call",
+			Bid:         0.5,
+			Ask:         1,
+			LongShort:   "long",
+		},
+	}
+
+	// the losses of both wings are equal. the first one is reported
+	assert.Equal(t, controllers.Extremum{Value: 200, Price: 95}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Value: -300, Price: 0}, controllers.CalculateMaxLoss(contracts))
+}
+
+func TestCalculateRatioSpread(t *testing.T) {
+	// 1x2 call ratio spread, for a net credit of 1
+	contracts := []options.OptionsContract{
+		{
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         4.5,
+			Ask:         5,
+			LongShort:   "long",
+		},
+		{
+			StrikePrice: 105,
+			OptionsType: "Call",
+			Bid:         3,
+			Ask:         3.5,
+			LongShort:   "short",
+			Quantity:    2,
+		},
+	}
+
+	assert.Equal(t, []controllers.XYValue{
+		{
+			X: 0,
+			Y: 100,
+		},
+		{
+			X: 100,
+			Y: 100,
+		},
+		{
+			X: 105,
+			Y: 600,
+		},
+		{
+			X: 111,
+			Y: 0,
+		},
+		{
+			X: 210,
+			Y: -9900,
+		},
+	}, controllers.CalculateXYValues(contracts))
+	assert.Equal(t, controllers.Extremum{Value: 600, Price: 105}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, controllers.CalculateMaxLoss(contracts))
+	assert.Equal(t, []float64{111}, controllers.CalculateBreakEvenPoints(contracts))
+}
+
+func TestCalculateCoveredCallAndCollar(t *testing.T) {
+	contracts := []options.OptionsContract{
+		{
+			OptionsType: "Stock",
+			EntryPrice:  100,
+			LongShort:   "long",
+			Quantity:    100,
+		},
+		{
+			StrikePrice: 105,
+			OptionsType: "Call",
+			Bid:         2,
+			Ask:         2.5,
+			LongShort:   "short",
+		},
+	}
+
+	assert.Equal(t, controllers.Extremum{Value: 700, Price: 105}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Value: -9800, Price: 0}, controllers.CalculateMaxLoss(contracts))
+	assert.Equal(t, []float64{98}, controllers.CalculateBreakEvenPoints(contracts))
+
+	// a protective put turns the covered call into a collar
+	contracts = append(contracts, options.OptionsContract{
+		StrikePrice: 95,
+		OptionsType: "Put",
+		Bid:         1,
+		Ask:         1.5,
+		LongShort:   "long",
+	})
+
+	assert.Equal(t, controllers.Extremum{Value: 550, Price: 105}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Value: -450, Price: 0}, controllers.CalculateMaxLoss(contracts))
+	assert.Equal(t, []float64{99.5}, controllers.CalculateBreakEvenPoints(contracts))
+}
+
+func TestCalculateShortStock(t *testing.T) {
+	contracts := []options.OptionsContract{
+		{
+			OptionsType: "stock",
+			EntryPrice:  50,
+			LongShort:   "short",
+			Quantity:    10,
+		},
+	}
+
+	assert.Equal(t, []controllers.XYValue{
+		{
+			X: 0,
+			Y: 500,
+		},
+		{
+			X: 50,
+			Y: 0,
+		},
+		{
+			X: 100,
+			Y: -500,
+		},
+	}, controllers.CalculateXYValues(contracts))
+	assert.Equal(t, controllers.Extremum{Value: 500, Price: 0}, controllers.CalculateMaxProfit(contracts))
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, controllers.CalculateMaxLoss(contracts))
+}
+
+func TestExtremumJSON(t *testing.T) {
+	res, err := json.Marshal(controllers.Extremum{Value: -28.04, Price: 100})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value": -28.04, "price": 100}`, (string)(res))
+
+	res, err = json.Marshal(controllers.Extremum{Unlimited: true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"value": "unlimited"}`, (string)(res))
+
+	extremum := controllers.Extremum{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"value": -28.04, "price": 100}`), &extremum))
+	assert.Equal(t, controllers.Extremum{Value: -28.04, Price: 100}, extremum)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"value": "unlimited"}`), &extremum))
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, extremum)
+}
+
+func TestCalculateBreakEvenPoints(t *testing.T) {
+	assert.Equal(t, []float64{116.27}, controllers.CalculateBreakEvenPoints([]options.OptionsContract{
 		{
 			StrikePrice: 100,
 			OptionsType: "Call",
@@ -210,3 +426,320 @@ func TestCalculateBreakEvenPoints(t * testing.T){
 	},
 	))
 }
+
+func TestCalculateBreakEvenPointsOfIronCondor(t *testing.T) {
+	assert.Equal(t, []float64{93, 107}, controllers.CalculateBreakEvenPoints([]options.OptionsContract{
+		{
+			StrikePrice: 90,
+			OptionsType: "Put",
+			Bid:         0.5,
+			Ask:         1,
+			LongShort:   "long",
+		},
+		{
+			StrikePrice: 95,
+			OptionsType: "Put",
+			Bid:         2,
+			Ask:         2.5,
+			LongShort:   "short",
+		},
+		{
+			StrikePrice: 105,
+			OptionsType: "Call",
+			Bid:         2,
+			Ask:         2.5,
+			LongShort:   "short",
+		},
+		{
+			StrikePrice: 110,
+			OptionsType: "Call",
+			Bid:         0.5,
+			Ask:         1,
+			LongShort:   "long",
+		},
+	},
+	))
+}
+
+func TestCalculateBreakEvenPointsBeyondMaxX(t *testing.T) {
+	// the payoff crosses zero beyond 2 * strike price
+	assert.Equal(t, []float64{250}, controllers.CalculateBreakEvenPoints([]options.OptionsContract{
+		{
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         150,
+			Ask:         155,
+			LongShort:   "short",
+		},
+	},
+	))
+}
+
+func TestCalculateBreakEvenPointsWithoutZeroCrossing(t *testing.T) {
+	// a long and a short call at the same strike price always lose the bid ask spread
+	assert.Equal(t, []float64{}, controllers.CalculateBreakEvenPoints([]options.OptionsContract{
+		{
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   "long",
+		},
+		{
+			StrikePrice: 100,
+			OptionsType: "Call",
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   "short",
+		},
+	},
+	))
+}
+
+func TestAnalysisHandlerNumberOfContracts(t *testing.T) {
+	contract := `{
+		"strike_price": 100,
+		"type": "Call",
+		"bid": 10.05,
+		"ask": 12.04,
+		"long_short": "long",
+		"expiration_date": "2099-12-17T00:00:00Z"
+	}`
+
+	analyze := func(n int) *httptest.ResponseRecorder {
+		contracts := make([]string, n)
+		for i := range contracts {
+			contracts[i] = contract
+		}
+		req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader("["+strings.Join(contracts, ",")+"]"))
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		return res
+	}
+
+	for n := 1; n <= 4; n++ {
+		t.Run(fmt.Sprintf("%d contracts", n), func(t *testing.T) {
+			res := analyze(n)
+			assert.Equal(t, http.StatusOK, res.Code)
+
+			resp := struct {
+				BreakEvenPoints []float64 `json:"break_even_points"`
+			}{}
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+			assert.Equal(t, []float64{112.04}, resp.BreakEvenPoints)
+		})
+	}
+
+	for _, n := range []int{0, 5} {
+		t.Run(fmt.Sprintf("error on %d contracts", n), func(t *testing.T) {
+			res := analyze(n)
+			assert.Equal(t, http.StatusBadRequest, res.Code)
+			assert.Equal(t, "application/problem+json", res.Header().Get("Content-Type"))
+			assert.JSONEq(t, fmt.Sprintf(`{
+				"type": "/problems/invalid-request",
+				"title": "Invalid request",
+				"status": 400,
+				"code": "invalid_request",
+				"detail": "contracts: invalid number of options contracts: got %d, expected 1 to 4",
+				"errors": [
+					{
+						"field": "contracts",
+						"code": "invalid_number_of_contracts",
+						"message": "invalid number of options contracts: got %d, expected 1 to 4"
+					}
+				]
+			}`, n, n), res.Body.String())
+		})
+	}
+
+	t.Run("configured maximum", func(t *testing.T) {
+		defer func(max int) { controllers.MaxOptionsContracts = max }(controllers.MaxOptionsContracts)
+		controllers.MaxOptionsContracts = 5
+
+		assert.Equal(t, http.StatusOK, analyze(5).Code)
+		assert.Equal(t, http.StatusBadRequest, analyze(6).Code)
+	})
+}
+
+func TestCalculateGreeks(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	expirationDate := now.AddDate(1, 0, 0)
+
+	greeks := controllers.CalculateGreeks([]options.OptionsContract{
+		{
+			StrikePrice:    100,
+			OptionsType:    "Call",
+			Bid:            10.05,
+			Ask:            12.04,
+			LongShort:      "long",
+			ExpirationDate: expirationDate,
+		},
+		{
+			StrikePrice:    100,
+			OptionsType:    "Put",
+			Bid:            5.2,
+			Ask:            5.9,
+			LongShort:      "short",
+			Quantity:       2,
+			ExpirationDate: expirationDate,
+		},
+		{
+			OptionsType: "Stock",
+			EntryPrice:  100,
+			LongShort:   "short",
+			Quantity:    50,
+		},
+	}, pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}, now)
+
+	assert.Len(t, greeks.Contracts, 3)
+	// 100 shares of a long call
+	assert.InDelta(t, 63.68, greeks.Contracts[0].Delta, 1e-2)
+	assert.InDelta(t, 1.88, greeks.Contracts[0].Gamma, 1e-2)
+	// 200 shares of a short put
+	assert.InDelta(t, 72.64, greeks.Contracts[1].Delta, 1e-2)
+	assert.InDelta(t, -3.75, greeks.Contracts[1].Gamma, 1e-2)
+	assert.InDelta(t, -75.04, greeks.Contracts[1].Vega, 1e-2)
+	// 50 shares of short stock
+	assert.Equal(t, pricing.Greeks{Delta: -50}, greeks.Contracts[2])
+
+	assert.InDelta(t, 86.32, greeks.Position.Delta, 1e-2)
+	assert.InDelta(t, -1.88, greeks.Position.Gamma, 1e-2)
+	assert.InDelta(t, greeks.Contracts[0].Theta+greeks.Contracts[1].Theta, greeks.Position.Theta, 1e-9)
+}
+
+func TestAnalysisHandlerGreeks(t *testing.T) {
+	contract := `{
+		"strike_price": 100,
+		"type": "Call",
+		"bid": 10.05,
+		"ask": 12.04,
+		"long_short": "long",
+		"expiration_date": "2099-12-17T00:00:00Z"
+	}`
+
+	analyze := func(body string) (int, controllers.AnalysisResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+
+		resp := controllers.AnalysisResponse{}
+		if res.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		}
+		return res.Code, resp
+	}
+
+	t.Run("no greeks without market", func(t *testing.T) {
+		code, resp := analyze("[" + contract + "]")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, resp.Greeks)
+
+		code, resp = analyze(`{"contracts": [` + contract + `]}`)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Nil(t, resp.Greeks)
+		assert.Equal(t, []float64{112.04}, resp.BreakEvenPoints)
+	})
+
+	t.Run("greeks with market", func(t *testing.T) {
+		code, resp := analyze(`{
+			"contracts": [` + contract + `],
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2},
+			"valuation_date": "2098-12-17T00:00:00Z"
+		}`)
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotNil(t, resp.Greeks)
+		assert.InDelta(t, 63.68, resp.Greeks.Position.Delta, 1e-2)
+		assert.Equal(t, resp.Greeks.Contracts[0], resp.Greeks.Position)
+	})
+
+	t.Run("error on invalid market", func(t *testing.T) {
+		code, _ := analyze(`{
+			"contracts": [` + contract + `],
+			"market": {"rate": 0.05, "volatility": 0.2}
+		}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestCalculateImpliedVolatilities(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	expirationDate := now.AddDate(1, 0, 0)
+
+	volatilities := controllers.CalculateImpliedVolatilities([]options.OptionsContract{
+		{
+			// theoretical value at 20% volatility is 10.45
+			StrikePrice:    100,
+			OptionsType:    "Call",
+			Bid:            10.40,
+			Ask:            10.50,
+			LongShort:      "long",
+			ExpirationDate: expirationDate,
+		},
+		{
+			// intrinsic value is 20
+			StrikePrice:    120,
+			OptionsType:    "Put",
+			Bid:            10,
+			Ask:            12,
+			LongShort:      "short",
+			ExpirationDate: expirationDate,
+		},
+		{
+			OptionsType: "Stock",
+			EntryPrice:  100,
+			LongShort:   "long",
+		},
+	}, pricing.Market{Spot: 100, Rate: 0.05}, now)
+
+	assert.Len(t, volatilities, 3)
+	assert.InDelta(t, 0.2, *volatilities[0].Mid, 1e-3)
+	assert.Less(t, *volatilities[0].Bid, *volatilities[0].Mid)
+	assert.Greater(t, *volatilities[0].Ask, *volatilities[0].Mid)
+	assert.Empty(t, volatilities[0].Error)
+
+	assert.Nil(t, volatilities[1].Mid)
+	assert.Nil(t, volatilities[1].Bid)
+	assert.Nil(t, volatilities[1].Ask)
+	assert.Contains(t, volatilities[1].Error, "no implied volatility: price 11.00 is below the intrinsic value")
+
+	assert.Equal(t, controllers.ImpliedVolatility{}, volatilities[2])
+}
+
+func TestAnalyzeMargin(t *testing.T) {
+	nakedPut := []options.OptionsContract{
+		{StrikePrice: 95, OptionsType: "Put", Bid: 2, Ask: 2.2, LongShort: "short", ExpirationDate: time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	// naked options need the spot price
+	resp := controllers.Analyze(controllers.AnalysisRequest{Contracts: nakedPut})
+	assert.Nil(t, resp.Margin)
+
+	resp = controllers.Analyze(controllers.AnalysisRequest{Contracts: nakedPut, Market: &pricing.Market{Spot: 100}})
+	assert.NotNil(t, resp.Margin)
+	// 20% of 100, less 5 out of the money
+	assert.Equal(t, 1500.0, resp.Margin.Requirement)
+}
+
+// the American legs of the curves are valued within the node budget. the greeks are not
+func TestAnalyzeAmericanWithinBudget(t *testing.T) {
+	defer func(budget int) { controllers.MaxAnalysisNodes = budget }(controllers.MaxAnalysisNodes)
+
+	valuationDate := time.Date(2099, 6, 17, 0, 0, 0, 0, time.UTC)
+	req := controllers.AnalysisRequest{
+		Contracts: []options.OptionsContract{
+			{OptionsType: "put", StrikePrice: 100, Bid: 4, Ask: 4.5, LongShort: "short", ExpirationDate: time.Date(2099, 9, 17, 0, 0, 0, 0, time.UTC), ExerciseStyle: "american"},
+			{OptionsType: "put", StrikePrice: 100, Bid: 6, Ask: 6.5, LongShort: "long", ExpirationDate: time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC), ExerciseStyle: "american"},
+		},
+		Market:        &pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.3},
+		ValuationDate: valuationDate,
+	}
+
+	precise := controllers.Analyze(req)
+	controllers.MaxAnalysisNodes = 1
+	coarse := controllers.Analyze(req)
+
+	assert.Equal(t, precise.Greeks, coarse.Greeks)
+	// at least 20 steps. a few percent off
+	assert.InDelta(t, precise.MaxProfit.Value, coarse.MaxProfit.Value, 0.05*math.Abs(precise.MaxProfit.Value))
+	assert.InDelta(t, precise.Curves[0].XYValues[50].Y, coarse.Curves[0].XYValues[50].Y, 5)
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+)
+
+// MaxBatchSize is the maximum number of analyses in a batch. it is configured at startup
+var MaxBatchSize = 500
+
+// MaxBatchNodes is the budget of binomial tree nodes for the American options of all the items of a batch. it is configured at startup
+var MaxBatchNodes = 100_000_000
+
+// BatchWorkers is the number of analyses of a batch run concurrently. it is configured at startup
+var BatchWorkers = runtime.NumCPU()
+
+// BatchRequest represents many analysis requests. each item is an analysis request, or a bare array of contracts.
+// items without a valuation date are valued at the same time
+type BatchRequest struct {
+	Items []json.RawMessage `json:"items"`
+}
+
+// BatchResponse represents the results of the items, in the order of the items
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult represents the analysis of an item, or the problem of an invalid item. the other items are analyzed anyway.
+// the analysis is marshaled with the item, so that an analysis that cannot be marshaled is an error of its item only
+type BatchResult struct {
+	Analysis json.RawMessage `json:"analysis,omitempty"`
+	Error    *Problem        `json:"error,omitempty"`
+}
+
+// each item is within MaxAnalysisNodes. all items are within MaxBatchNodes
+func (r BatchRequest) IsValid(now time.Time) error {
+	errs := appErrors.ValidationErrors{}
+	if len(r.Items) == 0 || len(r.Items) > MaxBatchSize {
+		errs.Add("items", fmt.Errorf("%w: got %d, expected 1 to %d", appErrors.ErrInvalidBatchSize, len(r.Items), MaxBatchSize))
+		return errs
+	}
+
+	if nodes := r.binomialNodes(now); nodes > MaxBatchNodes {
+		errs.Add("items", fmt.Errorf("%w: got %d binomial tree nodes, expected at most %d", appErrors.ErrInvalidBatchSize, nodes, MaxBatchNodes))
+		return errs
+	}
+	return nil
+}
+
+// estimated binomial tree nodes of the valid items. the invalid items are not analyzed
+func (r BatchRequest) binomialNodes(now time.Time) int {
+	nodes := 0
+	for _, item := range r.Items {
+		if req, err := decodeItem(item, now); err == nil && req.IsValid() == nil {
+			nodes += req.binomialNodes()
+		}
+	}
+	return nodes
+}
+
+func BatchHandler(w http.ResponseWriter, r *http.Request) {
+	req := BatchRequest{}
+	if err := readRequest(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	now := time.Now()
+	if err := req.IsValid(now); err != nil {
+		WriteError(w, appErrors.InvalidRequest(err))
+		return
+	}
+
+	results, err := AnalyzeBatch(r.Context(), req.Items, now)
+	if err != nil {
+		// the client is gone
+		return
+	}
+	writeResponse(w, BatchResponse{results})
+}
+
+// AnalyzeBatch analyzes the items with at most BatchWorkers analyses at a time. the results are in the order of the items.
+// once the context is done, the items not started are not analyzed, and the error of the context is returned
+func AnalyzeBatch(ctx context.Context, items []json.RawMessage, now time.Time) ([]BatchResult, error) {
+	results := make([]BatchResult, len(items))
+	indexes := make(chan int)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < min(max(BatchWorkers, 1), len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = analyzeItem(items[i], now)
+			}
+		}()
+	}
+
+dispatch:
+	for i := range items {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+// a panic analyzing an item is an internal error of the item, rather than of the server
+func analyzeItem(item json.RawMessage, now time.Time) (result BatchResult) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			problem := problemOf(appErrors.Internal(fmt.Errorf("panic: %v", recovered)))
+			result = BatchResult{Error: &problem}
+		}
+	}()
+
+	req, err := decodeItem(item, now)
+	if err != nil {
+		problem := problemOf(err)
+		return BatchResult{Error: &problem}
+	}
+
+	resp, err := AnalyzeRequest(req)
+	if err != nil {
+		problem := problemOf(err)
+		return BatchResult{Error: &problem}
+	}
+
+	analysis, err := json.Marshal(resp)
+	if err != nil {
+		problem := problemOf(appErrors.Internal(err))
+		return BatchResult{Error: &problem}
+	}
+	return BatchResult{Analysis: analysis}
+}
+
+// items without a valuation date are valued now
+func decodeItem(item json.RawMessage, now time.Time) (AnalysisRequest, error) {
+	req := AnalysisRequest{}
+	if err := json.Unmarshal(item, &req); err != nil {
+		return req, appErrors.MalformedRequest(err)
+	}
+	if req.ValuationDate.IsZero() {
+		req.ValuationDate = now
+	}
+	return req, nil
+}
@@ -0,0 +1,141 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchHandler(t *testing.T) {
+	analyze := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/analyze/batch", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.BatchHandler(res, req)
+		return res
+	}
+
+	longCall := func(strike float64) string {
+		return fmt.Sprintf(`[{"strike_price": %g, "type": "call", "bid": 10, "ask": 12, "long_short": "long", "expiration_date": "2099-12-17T00:00:00Z"}]`, strike)
+	}
+
+	t.Run("results in the order of the items", func(t *testing.T) {
+		defer func(workers int) { controllers.BatchWorkers = workers }(controllers.BatchWorkers)
+		controllers.BatchWorkers = 3
+
+		items := []string{}
+		for i := 0; i < 20; i++ {
+			items = append(items, longCall(float64(90+i)))
+		}
+		items[4] = `{"contracts": []}`
+		items[7] = `{"contracts": "long call"}`
+
+		res := analyze(`{"items": [` + strings.Join(items, ",") + `]}`)
+		require.Equal(t, http.StatusOK, res.Code)
+		resp := controllers.BatchResponse{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		require.Len(t, resp.Results, 20)
+
+		for i, result := range resp.Results {
+			switch i {
+			case 4:
+				assert.Nil(t, result.Analysis)
+				assert.Equal(t, "invalid_request", result.Error.Code)
+				assert.Equal(t, "invalid_number_of_contracts", result.Error.Errors[0].Code)
+			case 7:
+				assert.Nil(t, result.Analysis)
+				assert.Equal(t, "malformed_request", result.Error.Code)
+			default:
+				assert.Nil(t, result.Error)
+				analysis := controllers.AnalysisResponse{}
+				require.NoError(t, json.Unmarshal(result.Analysis, &analysis))
+				// a long call breaks even at the strike price and the premium
+				assert.Equal(t, []float64{float64(90+i) + 12}, analysis.BreakEvenPoints)
+			}
+		}
+	})
+
+	t.Run("an item out of bounds is an error of the item", func(t *testing.T) {
+		huge := `[{"type": "stock", "entry_price": 1e308, "long_short": "long"}]`
+		res := analyze(`{"items": [` + longCall(100) + `,` + huge + `]}`)
+		require.Equal(t, http.StatusOK, res.Code)
+		resp := controllers.BatchResponse{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		assert.NotNil(t, resp.Results[0].Analysis)
+		assert.Nil(t, resp.Results[1].Analysis)
+		assert.NotNil(t, resp.Results[1].Error)
+	})
+
+	t.Run("error on empty batch", func(t *testing.T) {
+		res := analyze(`{"items": []}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Field: "items", Code: "invalid_batch_size", Message: "invalid batch size: got 0, expected 1 to 500"}}, problem.Errors)
+	})
+
+	t.Run("error on batch over the cap", func(t *testing.T) {
+		defer func(max int) { controllers.MaxBatchSize = max }(controllers.MaxBatchSize)
+		controllers.MaxBatchSize = 2
+
+		res := analyze(`{"items": [` + strings.Join([]string{longCall(100), longCall(105), longCall(110)}, ",") + `]}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Field: "items", Code: "invalid_batch_size", Message: "invalid batch size: got 3, expected 1 to 2"}}, problem.Errors)
+	})
+
+	t.Run("error on batch over the node budget", func(t *testing.T) {
+		defer func(max int) { controllers.MaxBatchNodes = max }(controllers.MaxBatchNodes)
+		controllers.MaxBatchNodes = 1_000_000
+
+		american := `[{"strike_price": 100, "type": "put", "bid": 10, "ask": 12, "long_short": "long", "expiration_date": "2099-12-17T00:00:00Z", "exercise_style": "american"}]`
+		res := analyze(`{"items": [` + strings.Join([]string{longCall(100), american}, ",") + `]}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, "invalid_batch_size", problem.Errors[0].Code)
+
+		// European options cost no nodes. invalid items are not analyzed
+		res = analyze(`{"items": [` + strings.Join([]string{longCall(100), `{"contracts": []}`}, ",") + `]}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("error on malformed batch", func(t *testing.T) {
+		res := analyze(`{"items": [`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, "malformed_request", problem.Code)
+	})
+}
+
+func TestAnalyzeBatchCancelled(t *testing.T) {
+	items := []json.RawMessage{}
+	for i := 0; i < 100; i++ {
+		items = append(items, json.RawMessage(`[{"strike_price": 100, "type": "call", "bid": 10, "ask": 12, "long_short": "long", "expiration_date": "2099-12-17T00:00:00Z"}]`))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := controllers.AnalyzeBatch(ctx, items, time.Now())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// nothing is written to a client that is gone
+	req := httptest.NewRequest(http.MethodPost, "/analyze/batch", strings.NewReader(`{"items": [`+string(items[0])+`]}`)).WithContext(ctx)
+	res := httptest.NewRecorder()
+	controllers.BatchHandler(res, req)
+	assert.Empty(t, res.Body.String())
+
+	results, err := controllers.AnalyzeBatch(context.Background(), items[:2], time.Now())
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
@@ -0,0 +1,72 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/costs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisHandlerCosts(t *testing.T) {
+	analyze := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		return res
+	}
+
+	contract := `{
+		"strike_price": 100,
+		"type": "Call",
+		"bid": 10.05,
+		"ask": 12.05,
+		"long_short": "long",
+		"quantity": 2,
+		"expiration_date": "2099-12-17T00:00:00Z"
+	}`
+
+	t.Run("mid fill and commissions", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": [` + contract + `],
+			"costs": {"commission_per_contract": 0.65, "exchange_fee_per_contract": 0.1, "fee_per_order": 1, "fill": "mid"}
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		resp := controllers.AnalysisResponse{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		assert.Equal(t, &costs.Costs{Commissions: 1.3, ExchangeFees: 0.2, OrderFees: 1, Total: 2.5}, resp.Costs)
+		// a premium of 11.05 for 200 shares, and the costs
+		assert.Equal(t, controllers.Extremum{Value: -2212.5, Price: 0}, resp.MaxLoss)
+		assert.Equal(t, []float64{111.06}, resp.BreakEvenPoints)
+	})
+
+	t.Run("bid and ask", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": [` + contract + `],
+			"costs": {"fee_per_order": 1}
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		resp := controllers.AnalysisResponse{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		assert.Equal(t, &costs.Costs{OrderFees: 1, Total: 1, Slippage: 200}, resp.Costs)
+		assert.Equal(t, controllers.Extremum{Value: -2411, Price: 0}, resp.MaxLoss)
+	})
+
+	t.Run("error on custom fill without fill prices", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": [` + contract + `],
+			"costs": {"fill": "custom"}
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		leg := 0
+		assert.Equal(t, []controllers.ProblemError{{Leg: &leg, Field: "fill_price", Code: "invalid_fill_price", Message: "invalid fill price"}}, problem.Errors)
+	})
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// MaxEvaluationDates is the maximum number of evaluation dates of a request. each adds a curve
+const MaxEvaluationDates = 10
+
+// number of evenly spaced underlying prices of a pre-expiry curve, on top of the prices of the expiry curve.
+// curves before expiry are smooth and need more points than the piecewise linear expiry curve
+const curvePoints = 100
+
+// Curve represents the combined profits/ losses of all options at an evaluation date before expiry
+type Curve struct {
+	// "today", "halfway" or the evaluation date
+	Name     string    `json:"name"`
+	Date     time.Time `json:"date"`
+	XYValues []XYValue `json:"xy_values"`
+}
+
+// pre-expiry curves for today, halfway to the nearest expiry and all evaluation dates.
+// there are none for stock legs, which never expire
+func CalculateCurves(contracts []options.OptionsContract, market pricing.Market, now time.Time, evaluationDates []time.Time) []Curve {
+	return calculateCurves(contracts, market, now, evaluationDates, curveGrid(contracts))
+}
+
+// the curves at the given underlying prices
+func calculateCurves(contracts []options.OptionsContract, market pricing.Market, now time.Time, evaluationDates []time.Time, prices []float64) []Curve {
+	expirationDate, ok := options.NearestExpirationDate(contracts)
+	if !ok {
+		return nil
+	}
+
+	curve := func(name string, date time.Time) Curve {
+		xyValues := []XYValue{}
+		for _, x := range prices {
+			market.Spot = x
+			xyValues = append(xyValues, XYValue{x, CalculateTheoreticalProfitOrLoss(contracts, market, date)})
+		}
+		return Curve{Name: name, Date: date, XYValues: xyValues}
+	}
+
+	curves := []Curve{
+		curve("today", now),
+		curve("halfway", now.Add(expirationDate.Sub(now)/2)),
+	}
+	for _, date := range evaluationDates {
+		curves = append(curves, curve(date.Format(time.DateOnly), date))
+	}
+	return curves
+}
+
+// the curves net of the costs of opening the position
+func lessCosts(curves []Curve, total float64) {
+	for _, curve := range curves {
+		for i := range curve.XYValues {
+			curve.XYValues[i].Y = roundToCents(curve.XYValues[i].Y - total)
+		}
+	}
+}
+
+// CalculateTheoreticalProfitOrLoss returns the combined profit or loss of all options at the given time,
+// when the underlying is at the spot price of the market
+func CalculateTheoreticalProfitOrLoss(contracts []options.OptionsContract, market pricing.Market, now time.Time) float64 {
+	profitOrLoss := 0.0
+	for _, c := range contracts {
+		profitOrLoss += pricing.TheoreticalProfitOrLoss(c, market, now)
+	}
+	return roundToCents(profitOrLoss)
+}
+
+// the prices of the expiry curve, and evenly spaced prices in between
+func curveGrid(contracts []options.OptionsContract) []float64 {
+	prices := priceGrid(contracts)
+	xMin, xMax := prices[0], prices[len(prices)-1]
+	for i := 1; i < curvePoints; i++ {
+		prices = append(prices, roundToCents(xMin+(xMax-xMin)*float64(i)/curvePoints))
+	}
+
+	sort.Float64s(prices)
+	return slices.Compact(prices)
+}
+
+// evaluation dates need a volatility to price the options, and fall between the valuation date and the nearest expiration date
+func (r AnalysisRequest) validateEvaluationDates() error {
+	if len(r.EvaluationDates) == 0 {
+		return nil
+	}
+
+	errs := appErrors.ValidationErrors{}
+	if r.Market == nil || r.Market.Volatility == 0 {
+		errs.Add("market.volatility", fmt.Errorf("%w: evaluation dates need a volatility", appErrors.ErrInvalidVolatility))
+	}
+
+	if len(r.EvaluationDates) > MaxEvaluationDates {
+		errs.Add("evaluation_dates", fmt.Errorf("%w: got %d dates, expected at most %d", appErrors.ErrInvalidEvaluationDate, len(r.EvaluationDates), MaxEvaluationDates))
+		return errs.Err()
+	}
+
+	expirationDate, ok := options.NearestExpirationDate(r.Contracts)
+	if !ok {
+		errs.Add("evaluation_dates", fmt.Errorf("%w: no options expire", appErrors.ErrInvalidEvaluationDate))
+		return errs.Err()
+	}
+
+	for i, date := range r.EvaluationDates {
+		if date.Before(r.ValuationDate) || date.After(expirationDate) {
+			errs.Add(fmt.Sprintf("evaluation_dates[%d]", i), fmt.Errorf("%w: %s is not between %s and %s", appErrors.ErrInvalidEvaluationDate, date.Format(time.RFC3339), r.ValuationDate.Format(time.RFC3339), expirationDate.Format(time.RFC3339)))
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,143 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateCurves(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	expirationDate := now.AddDate(1, 0, 0)
+	contracts := []options.OptionsContract{
+		{
+			StrikePrice:    100,
+			OptionsType:    "Call",
+			Bid:            10.05,
+			Ask:            10.45,
+			LongShort:      "long",
+			ExpirationDate: expirationDate,
+		},
+	}
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	curves := controllers.CalculateCurves(contracts, market, now, []time.Time{expirationDate})
+	assert.Len(t, curves, 3)
+	assert.Equal(t, "today", curves[0].Name)
+	assert.Equal(t, now, curves[0].Date)
+	assert.Equal(t, "halfway", curves[1].Name)
+	assert.Equal(t, now.Add(365*24*time.Hour/2), curves[1].Date)
+	assert.Equal(t, "2099-12-17", curves[2].Name)
+
+	// the call is bought at its theoretical value
+	for _, xy := range curves[0].XYValues {
+		if xy.X == 100 {
+			assert.InDelta(t, 0, xy.Y, 1)
+		}
+	}
+
+	// time decay. the call loses value at the money as expiry approaches
+	at := func(curve controllers.Curve, x float64) float64 {
+		for _, xy := range curve.XYValues {
+			if xy.X == x {
+				return xy.Y
+			}
+		}
+		t.Fatalf("no value at %v", x)
+		return 0
+	}
+	assert.Greater(t, at(curves[0], 100), at(curves[1], 100))
+	assert.Greater(t, at(curves[1], 100), at(curves[2], 100))
+
+	// at expiry, the curve is the payoff
+	for _, xy := range curves[2].XYValues {
+		assert.InDelta(t, controllers.CalculateProfitOrLoss(contracts, xy.X), xy.Y, 0.01)
+	}
+}
+
+func TestCalculateCurvesOfStock(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+
+	// stock never expires
+	assert.Nil(t, controllers.CalculateCurves([]options.OptionsContract{
+		{
+			OptionsType: "Stock",
+			EntryPrice:  100,
+			LongShort:   "long",
+		},
+	}, pricing.Market{Spot: 100, Volatility: 0.2}, now, nil))
+}
+
+func TestAnalysisHandlerCurves(t *testing.T) {
+	analyze := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		return res
+	}
+	contracts := `[{
+		"strike_price": 100,
+		"type": "Call",
+		"bid": 10.05,
+		"ask": 12.04,
+		"long_short": "long",
+		"expiration_date": "2099-12-17T00:00:00Z"
+	}]`
+
+	t.Run("evaluation dates", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2},
+			"valuation_date": "2098-12-17T00:00:00Z",
+			"evaluation_dates": ["2099-03-17T00:00:00Z", "2099-09-17T00:00:00Z"]
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"name":"today"`)
+		assert.Contains(t, res.Body.String(), `"name":"halfway"`)
+		assert.Contains(t, res.Body.String(), `"name":"2099-03-17"`)
+		assert.Contains(t, res.Body.String(), `"name":"2099-09-17"`)
+	})
+
+	t.Run("error on evaluation date after expiry", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2},
+			"valuation_date": "2098-12-17T00:00:00Z",
+			"evaluation_dates": ["2100-03-17T00:00:00Z"]
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Contains(t, res.Body.String(), "invalid evaluation date")
+	})
+
+	t.Run("error on too many evaluation dates", func(t *testing.T) {
+		dates := strings.TrimSuffix(strings.Repeat(`"2099-03-17T00:00:00Z",`, controllers.MaxEvaluationDates+1), ",")
+		res := analyze(`{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2},
+			"valuation_date": "2098-12-17T00:00:00Z",
+			"evaluation_dates": [` + dates + `]
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Field: "evaluation_dates", Code: "invalid_evaluation_date", Message: "invalid evaluation date: got 11 dates, expected at most 10"}}, problem.Errors)
+	})
+
+	t.Run("error on evaluation date without volatility", func(t *testing.T) {
+		res := analyze(`{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 100, "rate": 0.05},
+			"evaluation_dates": ["2099-03-17T00:00:00Z"]
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Contains(t, res.Body.String(), "invalid volatility")
+	})
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"math"
+
+	"github.com/aries-financial-inc/options-service/costs"
+	"github.com/aries-financial-inc/options-service/margin"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// Entry is whether opening the position pays a net debit or receives a net credit
+type Entry string
+
+const (
+	DEBIT  Entry = "debit"
+	CREDIT Entry = "credit"
+)
+
+// Metrics represents the returns and the risks of the position, derived from the rest of the analysis.
+// the ratios are null when they are not defined, e.g. for an unlimited maximum profit or loss
+type Metrics struct {
+	Entry Entry `json:"entry"`
+	// the premiums paid or received for all legs, and the costs. never negative
+	NetPremium float64 `json:"net_premium"`
+	// the maximum loss over the maximum profit
+	RiskReward *float64 `json:"risk_reward"`
+	// the maximum profit over the maximum loss
+	ReturnOnMaxLoss *float64 `json:"return_on_max_loss"`
+	// the maximum profit over the margin requirement
+	ReturnOnMargin *float64 `json:"return_on_margin"`
+	// the distance of each break even point from the spot price, in percent of the spot price. only when the spot price or the market is given
+	BreakEvenDistances []float64 `json:"break_even_distances,omitempty"`
+}
+
+// spot is zero when it is not known
+func calculateMetrics(contracts []options.OptionsContract, resp AnalysisResponse, positionCosts *costs.Costs, positionMargin *margin.Margin, spot float64) Metrics {
+	net := 0.0
+	for _, c := range contracts {
+		net += c.LongShort.Sign() * c.Premium() * float64(c.Shares())
+	}
+	if positionCosts != nil {
+		net += positionCosts.Total
+	}
+
+	metrics := Metrics{Entry: DEBIT, NetPremium: roundToCents(math.Abs(net))}
+	if net < 0 {
+		metrics.Entry = CREDIT
+	}
+
+	maxProfit, maxLoss := resp.MaxProfit, resp.MaxLoss
+	profitable := !maxProfit.Unlimited && maxProfit.Value > 0
+	losing := !maxLoss.Unlimited && maxLoss.Value < 0
+	if profitable && losing {
+		metrics.RiskReward = ratio(-maxLoss.Value, maxProfit.Value)
+		metrics.ReturnOnMaxLoss = ratio(maxProfit.Value, -maxLoss.Value)
+	}
+	if profitable && positionMargin != nil && positionMargin.Requirement > 0 {
+		metrics.ReturnOnMargin = ratio(maxProfit.Value, positionMargin.Requirement)
+	}
+
+	if spot > 0 {
+		metrics.BreakEvenDistances = []float64{}
+		for _, b := range resp.BreakEvenPoints {
+			metrics.BreakEvenDistances = append(metrics.BreakEvenDistances, roundToCents((b-spot)/spot*100))
+		}
+	}
+	return metrics
+}
+
+// rounded to four decimal places
+func ratio(a, b float64) *float64 {
+	r := math.Round(a/b*10000) / 10000
+	return &r
+}
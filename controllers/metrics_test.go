@@ -0,0 +1,38 @@
+package controllers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeMetrics(t *testing.T) {
+	expirationDate := time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC)
+	bearCallSpread := []options.OptionsContract{
+		{StrikePrice: 100, OptionsType: "Call", Bid: 4, Ask: 4.2, LongShort: "short", ExpirationDate: expirationDate},
+		{StrikePrice: 110, OptionsType: "Call", Bid: 1, Ask: 1.2, LongShort: "long", ExpirationDate: expirationDate},
+	}
+
+	resp := controllers.Analyze(controllers.AnalysisRequest{Contracts: bearCallSpread, Market: &pricing.Market{Spot: 95}})
+	ratio := func(f float64) *float64 { return &f }
+	// a credit of 2.8, against a loss of 7.2 at most. the margin is the width of 10, less the credit
+	assert.Equal(t, controllers.Metrics{
+		Entry:              controllers.CREDIT,
+		NetPremium:         280,
+		RiskReward:         ratio(2.5714),
+		ReturnOnMaxLoss:    ratio(0.3889),
+		ReturnOnMargin:     ratio(0.3889),
+		BreakEvenDistances: []float64{8.21},
+	}, resp.Metrics)
+
+	// a naked short call has no risk reward
+	resp = controllers.Analyze(controllers.AnalysisRequest{Contracts: bearCallSpread[:1]})
+	assert.Nil(t, resp.Metrics.RiskReward)
+	assert.Nil(t, resp.Metrics.ReturnOnMaxLoss)
+	assert.Nil(t, resp.Metrics.ReturnOnMargin)
+	assert.Nil(t, resp.Metrics.BreakEvenDistances)
+}
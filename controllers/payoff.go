@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// payoff is the combined profit or loss of all options over the underlying price, at the nearest expiration date
+type payoff struct {
+	profitOrLoss func(price float64) float64
+	// sorted and deduplicated underlying prices at which the payoff is sampled. the last one is the maximum X
+	prices []float64
+	// the payoff is linear between consecutive prices and beyond the maximum X. otherwise it is smooth, and the prices are dense
+	linear bool
+}
+
+// all options expire together. their payoff is piecewise linear
+func expiryPayoff(contracts []options.OptionsContract) payoff {
+	return payoff{
+		profitOrLoss: func(price float64) float64 { return CalculateProfitOrLoss(contracts, price) },
+		prices:       priceGrid(contracts),
+		linear:       true,
+	}
+}
+
+// options expire at different dates. the options expiring first pay off at their intrinsic value,
+// and the options expiring later are valued with the pricing model at that time
+func nearestExpiryPayoff(contracts []options.OptionsContract, market pricing.Market) payoff {
+	expirationDate, _ := options.NearestExpirationDate(contracts)
+	return payoff{
+		profitOrLoss: func(price float64) float64 {
+			market.Spot = price
+			return CalculateTheoreticalProfitOrLoss(contracts, market, expirationDate)
+		},
+		prices: curveGrid(contracts),
+	}
+}
+
+// the payoff net of the costs of opening the position
+func (p payoff) lessCosts(total float64) payoff {
+	profitOrLoss := p.profitOrLoss
+	p.profitOrLoss = func(price float64) float64 { return roundToCents(profitOrLoss(price) - total) }
+	return p
+}
+
+// the contracts expire at different dates. stock legs never expire
+func hasMixedExpirations(contracts []options.OptionsContract) bool {
+	expirationDate := time.Time{}
+	for _, c := range contracts {
+		if c.OptionsType.Value() == options.STOCK {
+			continue
+		}
+		if !expirationDate.IsZero() && !c.ExpirationDate.Equal(expirationDate) {
+			return true
+		}
+		expirationDate = c.ExpirationDate
+	}
+	return false
+}
+
+func (p payoff) xMax() float64 {
+	return p.prices[len(p.prices)-1]
+}
+
+// the sampled prices and all break even points
+func (p payoff) xyValues() []XYValue {
+	return p.xyValuesAt(append(slices.Clone(p.prices), p.breakEvenPoints()...))
+}
+
+func (p payoff) xyValuesAt(prices []float64) []XYValue {
+	prices = slices.Clone(prices)
+	sort.Float64s(prices)
+
+	xyValues := []XYValue{}
+	for _, x := range slices.Compact(prices) {
+		xyValues = append(xyValues, XYValue{x, p.profitOrLoss(x)})
+	}
+	return xyValues
+}
+
+func (p payoff) maxProfit() Extremum {
+	if p.slopeBeyondMaxX() > 0 {
+		return Extremum{Unlimited: true}
+	}
+
+	maxProfit := Extremum{Value: math.Inf(-1)}
+	for _, x := range p.prices {
+		if y := p.profitOrLoss(x); y > maxProfit.Value {
+			maxProfit = Extremum{Value: y, Price: x}
+		}
+	}
+	return maxProfit
+}
+
+func (p payoff) maxLoss() Extremum {
+	if p.slopeBeyondMaxX() < 0 {
+		return Extremum{Unlimited: true}
+	}
+
+	maxLoss := Extremum{Value: math.Inf(1)}
+	for _, x := range p.prices {
+		if y := p.profitOrLoss(x); y < maxLoss.Value {
+			maxLoss = Extremum{Value: y, Price: x}
+		}
+	}
+	return maxLoss
+}
+
+// change in the payoff for a unit change of the underlying price beyond the maximum X.
+// it is the net call and stock exposure when the price is beyond all strike prices.
+// a smooth payoff only becomes linear far beyond the maximum X. an exposure of less than half a share is no exposure
+func (p payoff) slopeBeyondMaxX() float64 {
+	if p.linear {
+		return p.profitOrLoss(p.xMax()+1) - p.profitOrLoss(p.xMax())
+	}
+
+	far := 10 * p.xMax()
+	slope := p.profitOrLoss(far+1) - p.profitOrLoss(far)
+	if math.Abs(slope) < 0.5 {
+		return 0
+	}
+	return slope
+}
+
+// zero crossings between consecutive prices, and beyond the maximum X.
+// a linear payoff crosses zero where the line through consecutive prices does. a smooth payoff is bisected
+func (p payoff) breakEvenPoints() []float64 {
+	breakEvens := []float64{}
+	for i, x := range p.prices {
+		y := p.profitOrLoss(x)
+		if y == 0 {
+			breakEvens = append(breakEvens, x)
+			continue
+		}
+
+		if i+1 < len(p.prices) {
+			nextX := p.prices[i+1]
+			nextY := p.profitOrLoss(nextX)
+			if (y < 0 && nextY > 0) || (y > 0 && nextY < 0) {
+				breakEvens = append(breakEvens, p.zeroCrossing(x, y, nextX, nextY))
+			}
+		}
+	}
+
+	// beyond the maximum X, the payoff keeps moving towards zero if it has the opposite sign of the slope
+	xMax := p.xMax()
+	yMax := p.profitOrLoss(xMax)
+	slope := p.slopeBeyondMaxX()
+	if (yMax < 0 && slope > 0) || (yMax > 0 && slope < 0) {
+		if p.linear {
+			breakEvens = append(breakEvens, roundToCents(xMax-yMax/slope))
+		} else if x, y, ok := p.bracketBeyond(xMax, yMax); ok {
+			breakEvens = append(breakEvens, p.zeroCrossing(xMax, yMax, x, y))
+		}
+	}
+
+	sort.Float64s(breakEvens)
+	return slices.Compact(breakEvens)
+}
+
+// the payoff crosses zero between x and nextX
+func (p payoff) zeroCrossing(x, y, nextX, nextY float64) float64 {
+	if p.linear {
+		return roundToCents(x - y*(nextX-x)/(nextY-y))
+	}
+
+	// bisect to a cent
+	for nextX-x > 0.005 {
+		midX := (x + nextX) / 2
+		midY := p.profitOrLoss(midX)
+		if midY == 0 {
+			return roundToCents(midX)
+		}
+		if (midY < 0) == (y < 0) {
+			x, y = midX, midY
+		} else {
+			nextX = midX
+		}
+	}
+	return roundToCents((x + nextX) / 2)
+}
+
+// a price beyond x where the payoff has the opposite sign of y. the distance from x doubles until it is found
+func (p payoff) bracketBeyond(x, y float64) (float64, float64, bool) {
+	for step := x; step < 1024*x; step *= 2 {
+		nextY := p.profitOrLoss(x + step)
+		if (y < 0 && nextY >= 0) || (y > 0 && nextY <= 0) {
+			return x + step, nextY, true
+		}
+	}
+	return 0, 0, false
+}
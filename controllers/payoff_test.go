@@ -0,0 +1,132 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeCalendarSpread(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	nearExpirationDate := now.AddDate(0, 1, 0)
+	contracts := []options.OptionsContract{
+		{
+			StrikePrice:    100,
+			OptionsType:    "Call",
+			Bid:            2.5,
+			Ask:            2.7,
+			LongShort:      "short",
+			ExpirationDate: nearExpirationDate,
+		},
+		{
+			StrikePrice:    100,
+			OptionsType:    "Call",
+			Bid:            4.4,
+			Ask:            4.6,
+			LongShort:      "long",
+			ExpirationDate: now.AddDate(0, 4, 0),
+		},
+	}
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	resp := controllers.Analyze(controllers.AnalysisRequest{
+		Contracts:     contracts,
+		Market:        &market,
+		ValuationDate: now,
+	})
+
+	// the long call is worth about its time value when the short call expires at the money
+	assert.False(t, resp.MaxProfit.Unlimited)
+	assert.InDelta(t, 100, resp.MaxProfit.Price, 2)
+	assert.InDelta(t, 250, resp.MaxProfit.Value, 10)
+
+	// far from the strike price, both calls are worth their intrinsic values. the net debit is lost
+	assert.False(t, resp.MaxLoss.Unlimited)
+	assert.InDelta(t, -210, resp.MaxLoss.Value, 1)
+
+	assert.Len(t, resp.BreakEvenPoints, 2)
+	assert.Less(t, resp.BreakEvenPoints[0], 100.0)
+	assert.Greater(t, resp.BreakEvenPoints[1], 100.0)
+	for _, x := range resp.BreakEvenPoints {
+		market.Spot = x
+		assert.InDelta(t, 0, controllers.CalculateTheoreticalProfitOrLoss(contracts, market, nearExpirationDate), 1)
+	}
+}
+
+func TestAnalyzeRatioDiagonalSpread(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	resp := controllers.Analyze(controllers.AnalysisRequest{
+		Contracts: []options.OptionsContract{
+			{
+				StrikePrice:    105,
+				OptionsType:    "Call",
+				Bid:            1,
+				Ask:            1.2,
+				LongShort:      "short",
+				Quantity:       2,
+				ExpirationDate: now.AddDate(0, 1, 0),
+			},
+			{
+				StrikePrice:    100,
+				OptionsType:    "Call",
+				Bid:            4.4,
+				Ask:            4.6,
+				LongShort:      "long",
+				ExpirationDate: now.AddDate(0, 4, 0),
+			},
+		},
+		Market:        &market,
+		ValuationDate: now,
+	})
+
+	// one long call does not cover two short calls
+	assert.Equal(t, controllers.Extremum{Unlimited: true}, resp.MaxLoss)
+	assert.False(t, resp.MaxProfit.Unlimited)
+}
+
+func TestAnalysisHandlerMixedExpirationsNeedVolatility(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`[
+		{
+			"strike_price": 100,
+			"type": "Call",
+			"bid": 2.5,
+			"ask": 2.7,
+			"long_short": "short",
+			"expiration_date": "2099-01-17T00:00:00Z"
+		},
+		{
+			"strike_price": 100,
+			"type": "Call",
+			"bid": 4.4,
+			"ask": 4.6,
+			"long_short": "long",
+			"expiration_date": "2099-04-17T00:00:00Z"
+		}
+	]`))
+	res := httptest.NewRecorder()
+	controllers.AnalysisHandler(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.JSONEq(t, `{
+		"type": "/problems/invalid-request",
+		"title": "Invalid request",
+		"status": 400,
+		"code": "invalid_request",
+		"detail": "market.volatility: invalid volatility: mixed expiration dates need a volatility",
+		"errors": [
+			{
+				"field": "market.volatility",
+				"code": "invalid_volatility",
+				"message": "invalid volatility: mixed expiration dates need a volatility"
+			}
+		]
+	}`, res.Body.String())
+}
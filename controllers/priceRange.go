@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// bounds of the number of evenly spaced underlying prices of a range
+const (
+	DefaultRangePoints = 100
+	MaxRangePoints     = 1000
+)
+
+// bounds of the width of a range. the prices of wider ranges are not rounded to cents, or overflow
+const (
+	MaxRangePercent = 1000
+	MaxRangeStdDevs = 10
+	// the highest underlying price of a range. of standard deviations too, which grow with the volatility and the time to expiry
+	MaxRangePrice = 1000 * pricing.MaxSpot
+)
+
+// RangeMode is how the bounds of a range are given
+type RangeMode string
+
+const (
+	// from 0 to 2 * the maximum strike price
+	DEFAULT_RANGE RangeMode = ""
+	// a percent below and above the spot price
+	PERCENT RangeMode = "percent"
+	// the min and the max
+	EXPLICIT RangeMode = "explicit"
+	// standard deviations below and above the spot price, of the underlying price at the nearest expiration date
+	STD_DEVS RangeMode = "std_devs"
+)
+
+// values are case insensitive
+func (m RangeMode) Value() RangeMode {
+	return (RangeMode)(strings.ToLower((string)(m)))
+}
+
+// Range represents the underlying prices of the xy values and of the curves.
+// they are evenly spaced between the bounds, with the strike prices and the break even points in between.
+// the maximum profit, the maximum loss and the break even points are over all prices, in the range or not
+type Range struct {
+	Mode    RangeMode `json:"mode,omitempty"`
+	Percent float64   `json:"percent,omitempty"`
+	Min     float64   `json:"min,omitempty"`
+	Max     float64   `json:"max,omitempty"`
+	StdDevs float64   `json:"std_devs,omitempty"`
+	// defaults to DefaultRangePoints
+	Points int `json:"points,omitempty"`
+}
+
+// percent and standard deviations need the spot price. standard deviations need the market, for a volatility
+func (r Range) IsValid(spot float64, market *pricing.Market) error {
+	if r.Points < 0 || r.Points == 1 || r.Points > MaxRangePoints {
+		return fmt.Errorf("%w: got %d points, expected 2 to %d", appErrors.ErrInvalidRange, r.Points, MaxRangePoints)
+	}
+
+	switch r.Mode.Value() {
+	case DEFAULT_RANGE:
+	case PERCENT:
+		if r.Percent <= 0 || spot == 0 {
+			return fmt.Errorf("%w: percent needs a positive percent and the spot price", appErrors.ErrInvalidRange)
+		}
+		if !(r.Percent <= MaxRangePercent) {
+			return fmt.Errorf("%w: got %g percent, expected at most %d", appErrors.ErrInvalidRange, r.Percent, MaxRangePercent)
+		}
+	case EXPLICIT:
+		if !(r.Min >= 0 && r.Max > r.Min && r.Max <= MaxRangePrice) {
+			return fmt.Errorf("%w: expected 0 <= min < max <= %g", appErrors.ErrInvalidRange, float64(MaxRangePrice))
+		}
+	case STD_DEVS:
+		if r.StdDevs <= 0 || market == nil {
+			return fmt.Errorf("%w: std_devs needs a positive number of standard deviations and the market", appErrors.ErrInvalidRange)
+		}
+		if !(r.StdDevs <= MaxRangeStdDevs) {
+			return fmt.Errorf("%w: got %g standard deviations, expected at most %d", appErrors.ErrInvalidRange, r.StdDevs, MaxRangeStdDevs)
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %q", appErrors.ErrInvalidRange, r.Mode)
+	}
+	return nil
+}
+
+// the bounds of the range. standard deviations fall back to the default range without a distribution
+func (r Range) bounds(contracts []options.OptionsContract, spot float64, distribution *pricing.Lognormal) (float64, float64) {
+	switch r.Mode.Value() {
+	case PERCENT:
+		return math.Max(0, spot*(1-r.Percent/100)), spot * (1 + r.Percent/100)
+	case EXPLICIT:
+		return r.Min, r.Max
+	case STD_DEVS:
+		if distribution != nil {
+			deviation := r.StdDevs * distribution.Volatility * math.Sqrt(distribution.Years)
+			return spot * math.Exp(-deviation), math.Min(spot*math.Exp(deviation), MaxRangePrice)
+		}
+	}
+
+	prices := priceGrid(contracts)
+	return prices[0], prices[len(prices)-1]
+}
+
+// evenly spaced prices between the bounds, and the reference prices in between
+func (r Range) grid(contracts []options.OptionsContract, spot float64, distribution *pricing.Lognormal) []float64 {
+	xMin, xMax := r.bounds(contracts, spot, distribution)
+	xMin, xMax = roundToCents(xMin), roundToCents(xMax)
+
+	points := r.Points
+	if points == 0 {
+		points = DefaultRangePoints
+	}
+
+	prices := []float64{}
+	for i := 0; i < points; i++ {
+		prices = append(prices, roundToCents(xMin+(xMax-xMin)*float64(i)/float64(points-1)))
+	}
+	for _, c := range contracts {
+		prices = append(prices, c.ReferencePrice())
+	}
+	return between(prices, xMin, xMax)
+}
+
+// sorted and deduplicated prices between the bounds
+func between(prices []float64, xMin, xMax float64) []float64 {
+	prices = slices.DeleteFunc(slices.Clone(prices), func(x float64) bool { return x < xMin || x > xMax })
+	sort.Float64s(prices)
+	return slices.Compact(prices)
+}
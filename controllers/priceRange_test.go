@@ -0,0 +1,114 @@
+package controllers_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeRange(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	// a long call on a $5 stock
+	contracts := []options.OptionsContract{
+		{StrikePrice: 5, OptionsType: "Call", Bid: 0.4, Ask: 0.5, LongShort: "long", ExpirationDate: now.AddDate(1, 0, 0)},
+	}
+	xs := func(resp controllers.AnalysisResponse) []float64 {
+		xs := []float64{}
+		for _, xy := range resp.XYValues {
+			xs = append(xs, xy.X)
+		}
+		return xs
+	}
+
+	t.Run("percent around spot", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts: contracts,
+			Spot:      5,
+			Range:     &controllers.Range{Mode: "Percent", Percent: 20, Points: 5},
+		})
+		// evenly spaced, with the strike price and the break even point
+		assert.Equal(t, []float64{4, 4.5, 5, 5.5, 6}, xs(resp))
+		assert.Equal(t, controllers.XYValue{X: 5.5, Y: 0}, resp.XYValues[3])
+		// over all prices
+		assert.True(t, resp.MaxProfit.Unlimited)
+	})
+
+	t.Run("explicit", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts: contracts,
+			Range:     &controllers.Range{Mode: controllers.EXPLICIT, Min: 4.8, Max: 5.8, Points: 3},
+		})
+		assert.Equal(t, []float64{4.8, 5, 5.3, 5.5, 5.8}, xs(resp))
+	})
+
+	t.Run("standard deviations", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts:     contracts,
+			Market:        &pricing.Market{Spot: 5, Rate: 0.05, Volatility: 0.2},
+			ValuationDate: now,
+			Range:         &controllers.Range{Mode: controllers.STD_DEVS, StdDevs: 1, Points: 2},
+		})
+		// 5 * exp(-0.2) and 5 * exp(0.2)
+		assert.Equal(t, []float64{4.09, 5, 5.5, 6.11}, xs(resp))
+		for _, curve := range resp.Curves {
+			assert.Equal(t, []float64{4.09, 5, 6.11}, []float64{curve.XYValues[0].X, curve.XYValues[1].X, curve.XYValues[2].X})
+		}
+	})
+
+	t.Run("standard deviations of a volatile market", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts:     []options.OptionsContract{{StrikePrice: 5, OptionsType: "Call", Bid: 0.4, Ask: 0.5, LongShort: "long", ExpirationDate: now.AddDate(100, 0, 0)}},
+			Market:        &pricing.Market{Spot: 5, Volatility: pricing.MaxVolatility},
+			ValuationDate: now,
+			Range:         &controllers.Range{Mode: controllers.STD_DEVS, StdDevs: controllers.MaxRangeStdDevs, Points: 2},
+		})
+		// exp(1000) overflows
+		assert.Equal(t, float64(controllers.MaxRangePrice), resp.XYValues[len(resp.XYValues)-1].X)
+	})
+
+	t.Run("default bounds with more points", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts: contracts,
+			Range:     &controllers.Range{Points: 11},
+		})
+		assert.Equal(t, []float64{0, 1, 2, 3, 4, 5, 5.5, 6, 7, 8, 9, 10}, xs(resp))
+	})
+}
+
+func TestRangeIsValid(t *testing.T) {
+	market := &pricing.Market{Spot: 100}
+	assert.NoError(t, controllers.Range{}.IsValid(0, nil))
+	assert.NoError(t, controllers.Range{Mode: "percent", Percent: 10}.IsValid(100, nil))
+	assert.NoError(t, controllers.Range{Mode: "std_devs", StdDevs: 2}.IsValid(100, market))
+
+	assert.ErrorIs(t, controllers.Range{Points: 1}.IsValid(0, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Points: controllers.MaxRangePoints + 1}.IsValid(0, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "percent", Percent: 10}.IsValid(0, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "explicit", Min: 10, Max: 5}.IsValid(0, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "std_devs", StdDevs: 2}.IsValid(100, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "log"}.IsValid(100, nil), errors.ErrInvalidRange)
+
+	// wide ranges overflow
+	assert.NoError(t, controllers.Range{Mode: "percent", Percent: controllers.MaxRangePercent}.IsValid(100, nil))
+	assert.ErrorIs(t, controllers.Range{Mode: "percent", Percent: 1e308}.IsValid(100, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "std_devs", StdDevs: 1e6}.IsValid(100, market), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "explicit", Min: 10, Max: 1e308}.IsValid(0, nil), errors.ErrInvalidRange)
+	assert.ErrorIs(t, controllers.Range{Mode: "explicit", Min: math.NaN(), Max: 10}.IsValid(0, nil), errors.ErrInvalidRange)
+
+	// the spot price of the request is the spot price of the market
+	contract := options.OptionsContract{StrikePrice: 100, OptionsType: "Call", Bid: 1, Ask: 1.2, LongShort: "long", ExpirationDate: time.Now().AddDate(1, 0, 0)}
+	req := controllers.AnalysisRequest{Contracts: []options.OptionsContract{contract}, Spot: 90, Market: market}
+	assert.ErrorIs(t, req.IsValid(), errors.ErrInvalidSpotPrice)
+
+	// it is bounded like the spot price of the market
+	for _, spot := range []float64{-1, 1e308, math.NaN()} {
+		req := controllers.AnalysisRequest{Contracts: []options.OptionsContract{contract}, Spot: spot, Range: &controllers.Range{Mode: controllers.PERCENT, Percent: 10}}
+		assert.EqualError(t, req.IsValid(), "spot: invalid spot price: expected 0 to 1e+09")
+	}
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// PricingRequest represents the data structure of a request for theoretical values
+type PricingRequest struct {
+	Contracts []options.OptionsContract `json:"contracts"`
+	Market    pricing.Market            `json:"market"`
+	// the time at which the contracts are valued. defaults to now
+	ValuationDate time.Time `json:"valuation_date"`
+}
+
+// PricingResponse represents the data structure of the theoretical values, in the order of the contracts
+type PricingResponse struct {
+	Prices []Price `json:"prices"`
+}
+
+// Price represents the theoretical value of a share of a contract next to its quoted prices
+type Price struct {
+	TheoreticalValue float64 `json:"theoretical_value"`
+	Bid              float64 `json:"bid"`
+	Ask              float64 `json:"ask"`
+}
+
+func PricingHandler(w http.ResponseWriter, r *http.Request) {
+	req := PricingRequest{}
+	if err := readRequest(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if req.ValuationDate.IsZero() {
+		req.ValuationDate = time.Now()
+	}
+
+	errs := appErrors.ValidationErrors{}
+	errs.Add("", validateContracts(req.Contracts))
+	errs.Add("market", validateMarket(req.Market, req.Contracts, req.ValuationDate))
+	// theoretical values cannot be priced without volatility
+	if req.Market.Volatility == 0 {
+		errs.Add("market.volatility", appErrors.ErrInvalidVolatility)
+	}
+	if len(errs) > 0 {
+		WriteError(w, appErrors.InvalidRequest(errs))
+		return
+	}
+
+	resp := PricingResponse{Prices: CalculatePrices(req.Contracts, req.Market, req.ValuationDate)}
+
+	writeResponse(w, resp)
+}
+
+// theoretical values of all contracts, rounded to cents
+func CalculatePrices(contracts []options.OptionsContract, market pricing.Market, now time.Time) []Price {
+	prices := []Price{}
+	for _, c := range contracts {
+		prices = append(prices, Price{
+			TheoreticalValue: roundToCents(pricing.TheoreticalValue(c, market, now)),
+			Bid:              c.Bid,
+			Ask:              c.Ask,
+		})
+	}
+	return prices
+}
@@ -0,0 +1,178 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingHandler(t *testing.T) {
+	price := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/price", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.PricingHandler(res, req)
+		return res
+	}
+
+	t.Run("theoretical values", func(t *testing.T) {
+		res := price(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 12.04,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				},
+				{
+					"strike_price": 100,
+					"type": "Put",
+					"bid": 5.2,
+					"ask": 5.9,
+					"long_short": "short",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {
+				"spot": 100,
+				"rate": 0.05,
+				"volatility": 0.2
+			},
+			"valuation_date": "2098-12-17T00:00:00Z"
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{
+			"prices": [
+				{
+					"theoretical_value": 10.45,
+					"bid": 10.05,
+					"ask": 12.04
+				},
+				{
+					"theoretical_value": 5.57,
+					"bid": 5.2,
+					"ask": 5.9
+				}
+			]
+		}`, res.Body.String())
+	})
+
+	t.Run("american options and dividends", func(t *testing.T) {
+		res := price(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Put",
+					"bid": 5.9,
+					"ask": 6.3,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z",
+					"exercise_style": "american"
+				},
+				{
+					"strike_price": 100,
+					"type": "Put",
+					"bid": 5.2,
+					"ask": 5.9,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {
+				"spot": 100,
+				"rate": 0.05,
+				"volatility": 0.2,
+				"dividends": [{"date": "2099-06-17T00:00:00Z", "amount": 1}]
+			},
+			"valuation_date": "2098-12-17T00:00:00Z"
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		resp := controllers.PricingResponse{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		// the dividend raises the value of puts, and early exercise adds to it
+		assert.Greater(t, resp.Prices[1].TheoreticalValue, 5.57)
+		assert.Greater(t, resp.Prices[0].TheoreticalValue, resp.Prices[1].TheoreticalValue)
+	})
+
+	t.Run("error on invalid exercise style", func(t *testing.T) {
+		res := price(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 12.04,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z",
+					"exercise_style": "bermudan"
+				}
+			],
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2}
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		leg := 0
+		assert.Equal(t, []controllers.ProblemError{{Leg: &leg, Field: "exercise_style", Code: "invalid_exercise_style", Message: "invalid exercise style"}}, problem.Errors)
+	})
+
+	t.Run("error on invalid market", func(t *testing.T) {
+		res := price(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 12.04,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {
+				"spot": 100,
+				"rate": 0.05
+			}
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Field: "market.volatility", Code: "invalid_volatility", Message: "invalid volatility"}}, problem.Errors)
+	})
+
+	t.Run("error on dividends worth the spot price", func(t *testing.T) {
+		res := price(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 12.04,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {
+				"spot": 100,
+				"volatility": 0.2,
+				"dividends": [{"date": "2099-06-17T00:00:00Z", "amount": 500}]
+			},
+			"valuation_date": "2098-12-17T00:00:00Z"
+		}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Field: "market.dividends", Code: "invalid_dividend", Message: "invalid dividend: the dividends until expiry are worth 500, expected less than the spot price"}}, problem.Errors)
+	})
+
+	t.Run("error on no contracts", func(t *testing.T) {
+		res := price(`{"market": {"spot": 100, "rate": 0.05, "volatility": 0.2}}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"math"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// Probabilities represents the statistics of the payoff at the nearest expiration date,
+// under a lognormal distribution of the underlying price
+type Probabilities struct {
+	// the volatility of the distribution. the given one, or the average implied volatility of the options
+	Volatility             float64 `json:"volatility"`
+	ProbabilityOfProfit    float64 `json:"probability_of_profit"`
+	ProbabilityOfMaxProfit float64 `json:"probability_of_max_profit"`
+	// probability of touching each break even point before expiry, in the order of the break even points
+	BreakEvenTouchProbabilities []float64 `json:"break_even_touch_probabilities"`
+	ExpectedProfitOrLoss        float64   `json:"expected_profit_or_loss"`
+}
+
+// the distribution of the underlying price at the nearest expiration date.
+// without a volatility in the market, the average of the implied volatilities of the mid prices is used
+func expiryDistribution(contracts []options.OptionsContract, market pricing.Market, now time.Time, volatilities []ImpliedVolatility) (pricing.Lognormal, bool) {
+	expirationDate, ok := options.NearestExpirationDate(contracts)
+	if !ok || !expirationDate.After(now) {
+		return pricing.Lognormal{}, false
+	}
+
+	if market.Volatility == 0 {
+		sum, n := 0.0, 0
+		for _, v := range volatilities {
+			if v.Mid != nil {
+				sum += *v.Mid
+				n++
+			}
+		}
+		if n == 0 {
+			return pricing.Lognormal{}, false
+		}
+		market.Volatility = sum / float64(n)
+	}
+
+	return pricing.NewLognormal(market, pricing.YearsToExpiry(now, expirationDate)), true
+}
+
+func calculateProbabilities(p payoff, breakEvens []float64, maxProfit Extremum, distribution pricing.Lognormal) Probabilities {
+	probabilities := Probabilities{
+		Volatility:                  distribution.Volatility,
+		BreakEvenTouchProbabilities: []float64{},
+		ExpectedProfitOrLoss:        roundToCents(distribution.Expectation(p.profitOrLoss)),
+	}
+
+	// the payoff keeps its sign between consecutive break even points
+	bounds := append(append([]float64{0}, breakEvens...), math.Inf(1))
+	for i := 0; i+1 < len(bounds); i++ {
+		if p.profitOrLoss(pointBetween(bounds[i], bounds[i+1])) > 0 {
+			probabilities.ProbabilityOfProfit += distribution.Probability(bounds[i], bounds[i+1])
+		}
+	}
+
+	for _, x := range breakEvens {
+		probabilities.BreakEvenTouchProbabilities = append(probabilities.BreakEvenTouchProbabilities, distribution.TouchProbability(x))
+	}
+
+	// the max profit is reached over the flat parts of the payoff at its value. a single point has no probability
+	if !maxProfit.Unlimited {
+		for i := 0; i+1 < len(p.prices); i++ {
+			if p.profitOrLoss(p.prices[i]) == maxProfit.Value && p.profitOrLoss(p.prices[i+1]) == maxProfit.Value {
+				probabilities.ProbabilityOfMaxProfit += distribution.Probability(p.prices[i], p.prices[i+1])
+			}
+		}
+		if p.profitOrLoss(p.xMax()) == maxProfit.Value && p.slopeBeyondMaxX() == 0 {
+			probabilities.ProbabilityOfMaxProfit += distribution.Probability(p.xMax(), math.Inf(1))
+		}
+	}
+	return probabilities
+}
+
+// a price strictly between the bounds. the upper bound may be infinite
+func pointBetween(from, to float64) float64 {
+	if math.IsInf(to, 1) {
+		return 2*from + 1
+	}
+	return (from + to) / 2
+}
@@ -0,0 +1,83 @@
+package controllers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeProbabilities(t *testing.T) {
+	now := time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	expirationDate := now.AddDate(1, 0, 0)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+	distribution := pricing.NewLognormal(market, 1)
+	call := options.OptionsContract{
+		StrikePrice:    100,
+		OptionsType:    "Call",
+		Bid:            10.05,
+		Ask:            10.45,
+		LongShort:      "long",
+		ExpirationDate: expirationDate,
+	}
+
+	t.Run("long call", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts:     []options.OptionsContract{call},
+			Market:        &market,
+			ValuationDate: now,
+		})
+
+		assert.NotNil(t, resp.Probabilities)
+		assert.Equal(t, 0.2, resp.Probabilities.Volatility)
+		assert.InDelta(t, 1-distribution.CDF(110.45), resp.Probabilities.ProbabilityOfProfit, 1e-9)
+		assert.Equal(t, 0.0, resp.Probabilities.ProbabilityOfMaxProfit)
+		assert.Len(t, resp.Probabilities.BreakEvenTouchProbabilities, 1)
+		assert.InDelta(t, distribution.TouchProbability(110.45), resp.Probabilities.BreakEvenTouchProbabilities[0], 1e-9)
+		// the call is bought at its theoretical value. it grows at the risk free rate
+		assert.InDelta(t, 53.64, resp.Probabilities.ExpectedProfitOrLoss, 0.05)
+	})
+
+	t.Run("bull call spread", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts: []options.OptionsContract{call, {
+				StrikePrice:    110,
+				OptionsType:    "Call",
+				Bid:            6.05,
+				Ask:            6.45,
+				LongShort:      "short",
+				ExpirationDate: expirationDate,
+			}},
+			Market:        &market,
+			ValuationDate: now,
+		})
+
+		assert.Equal(t, []float64{104.4}, resp.BreakEvenPoints)
+		assert.InDelta(t, 1-distribution.CDF(104.4), resp.Probabilities.ProbabilityOfProfit, 1e-9)
+		// the max profit is made anywhere beyond the short strike
+		assert.InDelta(t, 1-distribution.CDF(110), resp.Probabilities.ProbabilityOfMaxProfit, 1e-9)
+	})
+
+	t.Run("implied volatility", func(t *testing.T) {
+		market := pricing.Market{Spot: 100, Rate: 0.05}
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts:     []options.OptionsContract{call},
+			Market:        &market,
+			ValuationDate: now,
+		})
+
+		assert.NotNil(t, resp.Probabilities)
+		assert.Equal(t, *resp.ImpliedVolatilities[0].Mid, resp.Probabilities.Volatility)
+	})
+
+	t.Run("no probabilities without market", func(t *testing.T) {
+		resp := controllers.Analyze(controllers.AnalysisRequest{
+			Contracts:     []options.OptionsContract{call},
+			ValuationDate: now,
+		})
+		assert.Nil(t, resp.Probabilities)
+	})
+}
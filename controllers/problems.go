@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+)
+
+// titles of the problems, by the code of the application error. the type of a problem is derived from its code
+var problemTitles = map[string]string{
+	appErrors.CodeInvalidRequest:   "Invalid request",
+	appErrors.CodeMalformedRequest: "Malformed request",
+	appErrors.CodeRequestTooLarge:  "Request too large",
+	appErrors.CodeNotFound:         "Not found",
+	appErrors.CodeMethodNotAllowed: "Method not allowed",
+	appErrors.CodeInternal:         "Internal error",
+}
+
+// Problem represents an RFC 7807 problem details response
+type Problem struct {
+	// "/problems/" and the code, with dashes. relative to the server
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// the code of the application error
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+	// every error of the request
+	Errors []ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError represents an error of a request. leg is the index of the contract, and field the JSON path of the field in it.
+// they are omitted for errors of the whole request
+type ProblemError struct {
+	Leg     *int   `json:"leg,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// WriteError writes any error as a problem, with the status and code it maps to
+func WriteError(w http.ResponseWriter, err error) {
+	problem := problemOf(err)
+	res, _ := json.Marshal(problem)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	w.Write(res)
+}
+
+// the problem of any error. the causes of internal errors are logged, and not exposed
+func problemOf(err error) Problem {
+	appErr := appErrors.From(err)
+	problem := Problem{
+		Type:   "/problems/" + strings.ReplaceAll(appErr.Code, "_", "-"),
+		Title:  problemTitles[appErr.Code],
+		Status: appErr.Status,
+		Code:   appErr.Code,
+		Detail: appErr.Error(),
+	}
+
+	switch appErr.Code {
+	case appErrors.CodeInternal:
+		log.Printf("internal error: %v", appErr.Err)
+		problem.Detail = ""
+	case appErrors.CodeInvalidRequest, appErrors.CodeNotFound:
+		validationErrors := appErrors.ValidationErrors{}
+		if !errors.As(appErr, &validationErrors) {
+			validationErrors = appErrors.ValidationErrors{{Err: appErr.Err}}
+		}
+		for _, e := range validationErrors {
+			problem.Errors = append(problem.Errors, ProblemError{e.Leg, e.Field, appErrors.Code(e.Err), e.Err.Error()})
+		}
+	}
+	return problem
+}
+
+// reads the JSON body of the request into req. a body over the size limit is too large, and any other failure is malformed
+func readRequest(r *http.Request, req any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if appErr := appErrors.From(err); appErr.Code == appErrors.CodeRequestTooLarge {
+			return appErr
+		}
+		return appErrors.MalformedRequest(err)
+	}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return appErrors.MalformedRequest(err)
+	}
+	return nil
+}
+
+// writes the response as JSON. it cannot fail for the response types of the service, and is an internal error otherwise
+func writeResponse(w http.ResponseWriter, resp any) {
+	res, err := json.Marshal(resp)
+	if err != nil {
+		WriteError(w, appErrors.Internal(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/aries-financial-inc/options-service/simulation"
+)
+
+// SimulationRequest represents the data structure of a request for a Monte Carlo simulation
+type SimulationRequest struct {
+	Contracts []options.OptionsContract `json:"contracts"`
+	Market    pricing.Market            `json:"market"`
+	// the start of the paths. defaults to now
+	ValuationDate time.Time         `json:"valuation_date"`
+	Simulation    simulation.Config `json:"simulation"`
+}
+
+func SimulationHandler(w http.ResponseWriter, r *http.Request) {
+	req := SimulationRequest{}
+	if err := readRequest(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if req.ValuationDate.IsZero() {
+		req.ValuationDate = time.Now()
+	}
+
+	errs := appErrors.ValidationErrors{}
+	errs.Add("", validateContracts(req.Contracts))
+	errs.Add("market", validateMarket(req.Market, req.Contracts, req.ValuationDate))
+	if len(errs) > 0 {
+		WriteError(w, appErrors.InvalidRequest(errs))
+		return
+	}
+
+	resp, err := simulation.Simulate(req.Contracts, req.Market, req.ValuationDate, req.Simulation)
+	if err != nil {
+		errs.Add("simulation", err)
+		WriteError(w, appErrors.InvalidRequest(errs))
+		return
+	}
+
+	writeResponse(w, resp)
+}
@@ -0,0 +1,62 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/simulation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulationHandler(t *testing.T) {
+	simulate := func(config string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/simulate", strings.NewReader(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 10.45,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {"spot": 100, "rate": 0.05, "volatility": 0.2},
+			"valuation_date": "2098-12-17T00:00:00Z",
+			"simulation": `+config+`
+		}`))
+		res := httptest.NewRecorder()
+		controllers.SimulationHandler(res, req)
+		return res
+	}
+
+	t.Run("seeded simulation", func(t *testing.T) {
+		res := simulate(`{"paths": 500, "steps": 5, "seed": 7, "bins": 10}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		result := simulation.Result{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &result))
+		assert.Equal(t, 500, result.Paths)
+		assert.Len(t, result.Histogram, 10)
+		assert.Equal(t, 1045.0, result.ValueAtRisk)
+
+		// the same seed gives the same result
+		assert.Equal(t, res.Body.String(), simulate(`{"paths": 500, "steps": 5, "seed": 7, "bins": 10}`).Body.String())
+	})
+
+	t.Run("error on too many paths", func(t *testing.T) {
+		res := simulate(`{"paths": 1000000}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{
+			Field:   "simulation",
+			Code:    "invalid_simulation_paths",
+			Message: "invalid number of simulation paths: got 1000000, expected 1 to 100000",
+		}}, problem.Errors)
+	})
+}
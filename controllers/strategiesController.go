@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/costs"
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/aries-financial-inc/options-service/strategies"
+)
+
+// StrategiesResponse represents the catalog of strategy templates
+type StrategiesResponse struct {
+	Strategies []strategies.Template  `json:"strategies"`
+	Parameters []strategies.Parameter `json:"parameters"`
+}
+
+// StrategyRequest represents the parameters of a template, next to the analysis inputs
+type StrategyRequest struct {
+	strategies.Parameters
+	Market          *pricing.Market `json:"market,omitempty"`
+	ValuationDate   time.Time       `json:"valuation_date"`
+	EvaluationDates []time.Time     `json:"evaluation_dates,omitempty"`
+	Costs           *costs.Model    `json:"costs,omitempty"`
+	Spot            float64         `json:"spot,omitempty"`
+	Range           *Range          `json:"range,omitempty"`
+}
+
+// StrategyResponse represents the contracts of a strategy and their analysis
+type StrategyResponse struct {
+	// the name of the template. the analysis names the strategy it recognizes
+	Template  string                    `json:"template"`
+	Contracts []options.OptionsContract `json:"contracts"`
+	AnalysisResponse
+}
+
+func StrategiesHandler(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, StrategiesResponse{strategies.Templates(), strategies.ParameterCatalog})
+}
+
+// expands the named template and analyzes its contracts
+func StrategyHandler(w http.ResponseWriter, r *http.Request, name string) {
+	template, err := strategies.Find(name)
+	if errors.Is(err, appErrors.ErrUnknownStrategy) {
+		WriteError(w, appErrors.NotFound(err))
+		return
+	}
+
+	req := StrategyRequest{}
+	if err := readRequest(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	contracts, err := template.Build(req.Parameters)
+	if err != nil {
+		WriteError(w, appErrors.InvalidRequest(err))
+		return
+	}
+
+	analysis := AnalysisRequest{
+		Contracts:       contracts,
+		Market:          req.Market,
+		ValuationDate:   req.ValuationDate,
+		EvaluationDates: req.EvaluationDates,
+		Costs:           req.Costs,
+		Spot:            req.Spot,
+		Range:           req.Range,
+	}
+	writeAnalysis(w, analysis, func(resp AnalysisResponse) any {
+		return StrategyResponse{template.Name, contracts, resp}
+	})
+}
@@ -0,0 +1,110 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategyHandler(t *testing.T) {
+	build := func(name, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/strategies/"+name, strings.NewReader(body))
+		res := httptest.NewRecorder()
+		controllers.StrategyHandler(res, req, name)
+		return res
+	}
+
+	t.Run("iron condor", func(t *testing.T) {
+		res := build("iron-condor", `{
+			"strike": 100,
+			"width": 10,
+			"quotes": [
+				{"bid": 1, "ask": 1.2},
+				{"bid": 2, "ask": 2.2},
+				{"bid": 2.1, "ask": 2.3},
+				{"bid": 1.1, "ask": 1.3}
+			],
+			"expiration_date": "2099-12-17T00:00:00Z"
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		resp := controllers.StrategyResponse{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		assert.Equal(t, "iron-condor", resp.Template)
+		assert.Len(t, resp.Contracts, 4)
+		// a credit of 2 + 2.1 - 1.2 - 1.3 = 1.6 per share
+		assert.Equal(t, controllers.Extremum{Value: 160, Price: 95}, resp.MaxProfit)
+		assert.Equal(t, controllers.Extremum{Value: -840, Price: 0}, resp.MaxLoss)
+		assert.Equal(t, []float64{93.4, 106.6}, resp.BreakEvenPoints)
+		// without the spot price
+		assert.Nil(t, resp.Metrics.BreakEvenDistances)
+	})
+
+	t.Run("spot and range", func(t *testing.T) {
+		res := build("straddle", `{
+			"strike": 100,
+			"quotes": [{"bid": 4.8, "ask": 5}, {"bid": 4.8, "ask": 5}],
+			"expiration_date": "2099-12-17T00:00:00Z",
+			"spot": 100,
+			"range": {"mode": "percent", "percent": 20, "points": 3}
+		}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		resp := controllers.StrategyResponse{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+		assert.Equal(t, []controllers.XYValue{{X: 80, Y: 1000}, {X: 90, Y: 0}, {X: 100, Y: -1000}, {X: 110, Y: 0}, {X: 120, Y: 1000}}, resp.XYValues)
+		assert.Equal(t, []float64{-10, 10}, resp.Metrics.BreakEvenDistances)
+	})
+
+	t.Run("error on unknown strategy", func(t *testing.T) {
+		res := build("jade-lizard", `{}`)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/not-found",
+			"title": "Not found",
+			"status": 404,
+			"code": "not_found",
+			"detail": "unknown strategy: \"jade-lizard\"",
+			"errors": [{"code": "unknown_strategy", "message": "unknown strategy: \"jade-lizard\""}]
+		}`, res.Body.String())
+	})
+
+	t.Run("error on missing quotes", func(t *testing.T) {
+		res := build("straddle", `{"strike": 100, "expiration_date": "2099-12-17T00:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, []controllers.ProblemError{{Code: "invalid_quotes", Message: "invalid quotes: got 0 quotes, expected 2"}}, problem.Errors)
+	})
+
+	t.Run("error on invalid contracts", func(t *testing.T) {
+		res := build("straddle", `{"strike": 100, "quotes": [{"bid": 5, "ask": 4}, {"bid": 5, "ask": 5.2}], "expiration_date": "2099-12-17T00:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		leg := 0
+		assert.Equal(t, []controllers.ProblemError{{Leg: &leg, Field: "ask", Code: "ask_bid_mismatch", Message: "ask price must be greater than bid price"}}, problem.Errors)
+	})
+}
+
+func TestStrategiesHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/strategies", nil)
+	res := httptest.NewRecorder()
+	controllers.StrategiesHandler(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	resp := controllers.StrategiesResponse{}
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+	names := []string{}
+	for _, s := range resp.Strategies {
+		names = append(names, s.Name)
+	}
+	assert.Contains(t, names, "iron-condor")
+	assert.Contains(t, names, "collar")
+	assert.NotEmpty(t, resp.Parameters)
+}
@@ -0,0 +1,140 @@
+// the costs of opening a position, on top of the premiums of its contracts
+package costs
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// FillAssumption is the price at which the options without a fill price are bought and sold.
+// a fill price is kept whatever the assumption, as it is without costs
+type FillAssumption string
+
+const (
+	// longs fill at the ask and shorts at the bid
+	BID_ASK FillAssumption = "bid_ask"
+	// all options fill at the middle of the bid and the ask
+	MID FillAssumption = "mid"
+	// every option fills at its fill price, which is required
+	CUSTOM FillAssumption = "custom"
+)
+
+// values are case insensitive. options fill at the bid and ask unless specified otherwise
+func (f FillAssumption) IsValid() error {
+	switch f.Value() {
+	case BID_ASK, MID, CUSTOM:
+	default:
+		return appErrors.ErrInvalidFill
+	}
+	return nil
+}
+
+func (f FillAssumption) Value() FillAssumption {
+	if f == "" {
+		return BID_ASK
+	}
+	return (FillAssumption)(strings.ToLower((string)(f)))
+}
+
+// Model represents the costs of trading options. stock legs pay the fee per order only
+type Model struct {
+	CommissionPerContract  float64        `json:"commission_per_contract"`
+	ExchangeFeePerContract float64        `json:"exchange_fee_per_contract"`
+	FeePerOrder            float64        `json:"fee_per_order"`
+	Fill                   FillAssumption `json:"fill"`
+}
+
+// MaxFee is the maximum commission and exchange fee per contract, and fee per order. higher fees are not fees, and overflow the totals
+const MaxFee = 1_000_000
+
+// Costs represents the costs of opening a position. the total is taken off its profit or loss.
+// slippage is the part of the premiums lost to filling away from the mid prices. it is in the premiums already
+type Costs struct {
+	Commissions  float64 `json:"commissions"`
+	ExchangeFees float64 `json:"exchange_fees"`
+	OrderFees    float64 `json:"order_fees"`
+	Total        float64 `json:"total"`
+	Slippage     float64 `json:"slippage"`
+}
+
+// a custom fill needs a fill price for every option. all errors are returned, by field.
+// the comparisons reject NaN, which JSON cannot carry but protobuf can
+func (m Model) IsValid(contracts []options.OptionsContract) error {
+	errs := appErrors.ValidationErrors{}
+	validateFee(&errs, "commission_per_contract", m.CommissionPerContract)
+	validateFee(&errs, "exchange_fee_per_contract", m.ExchangeFeePerContract)
+	validateFee(&errs, "fee_per_order", m.FeePerOrder)
+
+	errs.Add("fill", m.Fill.IsValid())
+
+	if m.Fill.Value() == CUSTOM {
+		for i, c := range contracts {
+			if c.OptionsType.Value() != options.STOCK && !(c.FillPrice > 0) {
+				errs.AddLeg(i, appErrors.ValidationErrors{{Field: "fill_price", Err: appErrors.ErrInvalidFillPrice}})
+			}
+		}
+	}
+	return errs.Err()
+}
+
+// a fee is 0 to MaxFee
+func validateFee(errs *appErrors.ValidationErrors, field string, fee float64) {
+	if !(fee >= 0) {
+		errs.Add(field, appErrors.ErrInvalidCosts)
+	} else if fee > MaxFee {
+		errs.Add(field, fmt.Errorf("%w: expected at most %d", appErrors.ErrInvalidCosts, MaxFee))
+	}
+}
+
+// Filled returns the contracts with the fill prices of the fill assumption, for the options without one
+func (m Model) Filled(contracts []options.OptionsContract) []options.OptionsContract {
+	filled := []options.OptionsContract{}
+	for _, c := range contracts {
+		if m.Fill.Value() == MID && c.FillPrice == 0 {
+			c.FillPrice = mid(c)
+		}
+		filled = append(filled, c)
+	}
+	return filled
+}
+
+// Calculate returns the costs of opening the filled contracts. a position is one order
+func (m Model) Calculate(filled []options.OptionsContract) Costs {
+	costs := Costs{OrderFees: m.FeePerOrder}
+	for _, c := range filled {
+		if c.OptionsType.Value() == options.STOCK {
+			continue
+		}
+
+		contracts := c.Shares() / sharesPerContract(c)
+		costs.Commissions += m.CommissionPerContract * float64(contracts)
+		costs.ExchangeFees += m.ExchangeFeePerContract * float64(contracts)
+		// paying more than the mid, or receiving less
+		costs.Slippage += c.LongShort.Sign() * (c.Premium() - mid(c)) * float64(c.Shares())
+	}
+
+	costs.Commissions = roundToCents(costs.Commissions)
+	costs.ExchangeFees = roundToCents(costs.ExchangeFees)
+	costs.Total = roundToCents(costs.Commissions + costs.ExchangeFees + costs.OrderFees)
+	costs.Slippage = roundToCents(costs.Slippage)
+	return costs
+}
+
+func sharesPerContract(c options.OptionsContract) int {
+	if c.Multiplier > 0 {
+		return c.Multiplier
+	}
+	return options.DefaultMultiplier
+}
+
+func mid(c options.OptionsContract) float64 {
+	return (c.Bid + c.Ask) / 2
+}
+
+func roundToCents(f float64) float64 {
+	return math.Round(f*100) / 100
+}
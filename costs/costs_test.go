@@ -0,0 +1,65 @@
+package costs_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/costs"
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/stretchr/testify/assert"
+)
+
+var contracts = []options.OptionsContract{
+	{OptionsType: "Call", StrikePrice: 100, Bid: 10, Ask: 10.4, LongShort: "long", Quantity: 2},
+	{OptionsType: "Call", StrikePrice: 110, Bid: 5, Ask: 5.2, LongShort: "short", Quantity: 2},
+	{OptionsType: "Stock", EntryPrice: 100, LongShort: "long", Quantity: 200},
+}
+
+func TestCalculate(t *testing.T) {
+	model := costs.Model{CommissionPerContract: 0.65, ExchangeFeePerContract: 0.05, FeePerOrder: 1}
+	assert.Equal(t, costs.Costs{
+		Commissions:  2.6,
+		ExchangeFees: 0.2,
+		OrderFees:    1,
+		Total:        3.8,
+		// half the spreads of 0.4 and 0.2, for 200 shares each
+		Slippage: 60,
+	}, model.Calculate(model.Filled(contracts)))
+}
+
+func TestFilled(t *testing.T) {
+	filled := costs.Model{Fill: "Mid"}.Filled(contracts)
+	assert.Equal(t, 10.2, filled[0].Premium())
+	assert.Equal(t, 5.1, filled[1].Premium())
+	assert.Equal(t, 100.0, filled[2].Premium())
+	assert.Equal(t, 0.0, costs.Model{Fill: costs.MID}.Calculate(filled).Slippage)
+
+	custom := append([]options.OptionsContract{}, contracts...)
+	custom[0].FillPrice = 10.3
+	custom[1].FillPrice = 5.15
+	filled = costs.Model{Fill: costs.CUSTOM}.Filled(custom)
+	assert.Equal(t, 10.3, filled[0].Premium())
+	// paying 0.1 over the mid, and receiving 0.05 over it
+	assert.Equal(t, 10.0, costs.Model{}.Calculate(filled).Slippage)
+
+	// the fill prices are kept, as they are without costs. the other options fill at the assumption
+	custom[1].FillPrice = 0
+	filled = costs.Model{}.Filled(custom)
+	assert.Equal(t, 10.3, filled[0].Premium())
+	assert.Equal(t, 5.0, filled[1].Premium())
+	filled = costs.Model{Fill: costs.MID}.Filled(custom)
+	assert.Equal(t, 10.3, filled[0].Premium())
+	assert.Equal(t, 5.1, filled[1].Premium())
+}
+
+func TestIsValid(t *testing.T) {
+	assert.NoError(t, costs.Model{}.IsValid(contracts))
+	assert.ErrorIs(t, costs.Model{CommissionPerContract: -1}.IsValid(contracts), errors.ErrInvalidCosts)
+	assert.ErrorIs(t, costs.Model{CommissionPerContract: 1e308}.IsValid(contracts), errors.ErrInvalidCosts)
+	assert.ErrorIs(t, costs.Model{ExchangeFeePerContract: math.NaN()}.IsValid(contracts), errors.ErrInvalidCosts)
+	assert.ErrorIs(t, costs.Model{FeePerOrder: costs.MaxFee + 1}.IsValid(contracts), errors.ErrInvalidCosts)
+	assert.NoError(t, costs.Model{CommissionPerContract: costs.MaxFee, ExchangeFeePerContract: costs.MaxFee, FeePerOrder: costs.MaxFee}.IsValid(contracts))
+	assert.ErrorIs(t, costs.Model{Fill: "last"}.IsValid(contracts), errors.ErrInvalidFill)
+	assert.ErrorIs(t, costs.Model{Fill: "custom"}.IsValid(contracts), errors.ErrInvalidFillPrice)
+}
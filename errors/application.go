@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// stable codes of application errors. clients switch on them
+const (
+	// the body of the request cannot be read, or is not valid JSON
+	CodeMalformedRequest = "malformed_request"
+	// the request is well formed, but some fields are not valid. the validation errors have codes of their own
+	CodeInvalidRequest = "invalid_request"
+	// the body of the request is larger than the limit of the server
+	CodeRequestTooLarge = "request_too_large"
+	// the resource does not exist, e.g. an unknown strategy
+	CodeNotFound = "not_found"
+	// the method is not allowed on the resource
+	CodeMethodNotAllowed = "method_not_allowed"
+	// the server failed. the cause is not exposed
+	CodeInternal = "internal_error"
+)
+
+// Error represents an application error with a stable code and the HTTP status it is answered with
+type Error struct {
+	Code   string
+	Status int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func MalformedRequest(err error) *Error {
+	return &Error{CodeMalformedRequest, http.StatusBadRequest, err}
+}
+
+func InvalidRequest(err error) *Error {
+	return &Error{CodeInvalidRequest, http.StatusBadRequest, err}
+}
+
+func RequestTooLarge(err error) *Error {
+	return &Error{CodeRequestTooLarge, http.StatusRequestEntityTooLarge, err}
+}
+
+func NotFound(err error) *Error {
+	return &Error{CodeNotFound, http.StatusNotFound, err}
+}
+
+func MethodNotAllowed(err error) *Error {
+	return &Error{CodeMethodNotAllowed, http.StatusMethodNotAllowed, err}
+}
+
+func Internal(err error) *Error {
+	return &Error{CodeInternal, http.StatusInternalServerError, err}
+}
+
+// From maps any error to an application error, wrapping it. it is the one place where errors get their status.
+// sentinel and validation errors are invalid requests, except the ones of missing resources. unknown errors are internal
+func From(err error) *Error {
+	appErr := &Error{}
+	if errors.As(err, &appErr) {
+		return &Error{appErr.Code, appErr.Status, err}
+	}
+
+	maxBytesErr := &http.MaxBytesError{}
+	if errors.As(err, &maxBytesErr) {
+		return RequestTooLarge(err)
+	}
+
+	if errors.Is(err, ErrUnknownStrategy) {
+		return NotFound(err)
+	}
+
+	validationErrs := ValidationErrors{}
+	if errors.As(err, &validationErrs) {
+		return InvalidRequest(err)
+	}
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return InvalidRequest(err)
+		}
+	}
+
+	return Internal(err)
+}
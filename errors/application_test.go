@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrom(t *testing.T) {
+	validationErrs := errors.ValidationErrors{}
+	validationErrs.Add("market.spot", errors.ErrInvalidSpotPrice)
+
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		status int
+	}{
+		{"application error", errors.MalformedRequest(fmt.Errorf("unexpected end of JSON input")), errors.CodeMalformedRequest, http.StatusBadRequest},
+		{"wrapped application error", fmt.Errorf("reading: %w", errors.RequestTooLarge(fmt.Errorf("too large"))), errors.CodeRequestTooLarge, http.StatusRequestEntityTooLarge},
+		{"validation errors", validationErrs, errors.CodeInvalidRequest, http.StatusBadRequest},
+		{"sentinel error", fmt.Errorf("%w: got 0", errors.ErrInvalidNumberOfContracts), errors.CodeInvalidRequest, http.StatusBadRequest},
+		{"unknown strategy", fmt.Errorf("%w: \"jade-lizard\"", errors.ErrUnknownStrategy), errors.CodeNotFound, http.StatusNotFound},
+		{"size limit", &http.MaxBytesError{Limit: 1024}, errors.CodeRequestTooLarge, http.StatusRequestEntityTooLarge},
+		{"unknown error", fmt.Errorf("connection reset"), errors.CodeInternal, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := errors.From(tt.err)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, tt.status, appErr.Status)
+			assert.Equal(t, tt.err.Error(), appErr.Error())
+		})
+	}
+}
@@ -10,4 +10,86 @@ var (
 	ErrAskBidMismatch        = errors.New("ask price must be greater than bid price")
 	ErrInvalidExpirationDate = errors.New("invalid expiration date")
 	ErrInvalidLongShort      = errors.New("invalid longShort")
+	ErrInvalidQuantity       = errors.New("invalid quantity")
+	ErrInvalidMultiplier     = errors.New("invalid multiplier")
+	ErrInvalidEntryPrice     = errors.New("invalid entry price")
+	ErrInvalidExerciseStyle  = errors.New("invalid exercise style")
+	ErrInvalidFillPrice      = errors.New("invalid fill price")
+
+	ErrInvalidNumberOfContracts = errors.New("invalid number of options contracts")
+
+	ErrInvalidSpotPrice     = errors.New("invalid spot price")
+	ErrInvalidVolatility    = errors.New("invalid volatility")
+	ErrInvalidDividend      = errors.New("invalid dividend")
+	ErrInvalidRate          = errors.New("invalid rate")
+	ErrInvalidDividendYield = errors.New("invalid dividend yield")
+
+	ErrNoImpliedVolatility = errors.New("no implied volatility")
+
+	ErrInvalidEvaluationDate = errors.New("invalid evaluation date")
+	ErrInvalidRange          = errors.New("invalid range")
+
+	ErrInvalidSimulationPaths   = errors.New("invalid number of simulation paths")
+	ErrInvalidSimulationSteps   = errors.New("invalid number of simulation steps")
+	ErrInvalidSimulationBins    = errors.New("invalid number of histogram bins")
+	ErrInvalidSimulationHorizon = errors.New("invalid simulation horizon")
+	ErrInvalidConfidence        = errors.New("invalid confidence level")
+	ErrInvalidJumps             = errors.New("invalid jumps")
+
+	ErrUnknownStrategy = errors.New("unknown strategy")
+	ErrInvalidQuotes   = errors.New("invalid quotes")
+
+	ErrInvalidFill  = errors.New("invalid fill assumption")
+	ErrInvalidCosts = errors.New("invalid costs")
+
+	ErrInvalidBatchSize = errors.New("invalid batch size")
 )
+
+// error codes of the sentinel errors, for API consumers
+var codes = []struct {
+	err  error
+	code string
+}{
+	{ErrInvalidOptionsType, "invalid_options_type"},
+	{ErrInvalidStrikePrice, "invalid_strike_price"},
+	{ErrInvalidAskPrice, "invalid_ask_price"},
+	{ErrInvalidBidPrice, "invalid_bid_price"},
+	{ErrAskBidMismatch, "ask_bid_mismatch"},
+	{ErrInvalidExpirationDate, "invalid_expiration_date"},
+	{ErrInvalidLongShort, "invalid_long_short"},
+	{ErrInvalidQuantity, "invalid_quantity"},
+	{ErrInvalidMultiplier, "invalid_multiplier"},
+	{ErrInvalidEntryPrice, "invalid_entry_price"},
+	{ErrInvalidExerciseStyle, "invalid_exercise_style"},
+	{ErrInvalidFillPrice, "invalid_fill_price"},
+	{ErrInvalidNumberOfContracts, "invalid_number_of_contracts"},
+	{ErrInvalidSpotPrice, "invalid_spot_price"},
+	{ErrInvalidVolatility, "invalid_volatility"},
+	{ErrInvalidDividend, "invalid_dividend"},
+	{ErrInvalidRate, "invalid_rate"},
+	{ErrInvalidDividendYield, "invalid_dividend_yield"},
+	{ErrNoImpliedVolatility, "no_implied_volatility"},
+	{ErrInvalidEvaluationDate, "invalid_evaluation_date"},
+	{ErrInvalidRange, "invalid_range"},
+	{ErrInvalidSimulationPaths, "invalid_simulation_paths"},
+	{ErrInvalidSimulationSteps, "invalid_simulation_steps"},
+	{ErrInvalidSimulationBins, "invalid_simulation_bins"},
+	{ErrInvalidSimulationHorizon, "invalid_simulation_horizon"},
+	{ErrInvalidConfidence, "invalid_confidence"},
+	{ErrInvalidJumps, "invalid_jumps"},
+	{ErrUnknownStrategy, "unknown_strategy"},
+	{ErrInvalidQuotes, "invalid_quotes"},
+	{ErrInvalidFill, "invalid_fill"},
+	{ErrInvalidCosts, "invalid_costs"},
+	{ErrInvalidBatchSize, "invalid_batch_size"},
+}
+
+// Code returns the code of the sentinel error that err wraps. "invalid_request" for other errors
+func Code(err error) string {
+	for _, c := range codes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return "invalid_request"
+}
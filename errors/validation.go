@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError represents an error of a field of a request, e.g. "strike_price" of the contract at leg 1.
+// leg is nil for the fields of the request itself
+type FieldError struct {
+	Leg   *int
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	path := e.Path()
+	if path == "" {
+		return e.Err.Error()
+	}
+	return path + ": " + e.Err.Error()
+}
+
+// Path returns the path of the field in the request, e.g. "contracts[1].strike_price". empty for errors of the whole request
+func (e FieldError) Path() string {
+	if e.Leg != nil {
+		return joinField(fmt.Sprintf("contracts[%d]", *e.Leg), e.Field)
+	}
+	return e.Field
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors represents all the errors of a request, rather than the first one
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// errors.Is and errors.As match any of the errors
+func (e ValidationErrors) Unwrap() []error {
+	errs := []error{}
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Add adds the error of the field. the fields of nested validation errors are prefixed with the field,
+// unless they are of a leg
+func (e *ValidationErrors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	nested := ValidationErrors{}
+	if errors.As(err, &nested) {
+		for _, n := range nested {
+			if n.Leg == nil {
+				n.Field = joinField(field, n.Field)
+			}
+			*e = append(*e, n)
+		}
+		return
+	}
+	*e = append(*e, FieldError{Field: field, Err: err})
+}
+
+// AddLeg adds the errors of the contract at the leg
+func (e *ValidationErrors) AddLeg(leg int, err error) {
+	if err == nil {
+		return
+	}
+
+	nested := ValidationErrors{}
+	if !errors.As(err, &nested) {
+		nested = ValidationErrors{{Err: err}}
+	}
+	for _, n := range nested {
+		n.Leg = &leg
+		*e = append(*e, n)
+	}
+}
+
+// Err is nil when there are no errors. a nil ValidationErrors in an error interface is not nil
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// a field of an object is joined with a dot, and an index of an array without one
+func joinField(parent, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "":
+		return parent
+	case strings.HasPrefix(field, "["):
+		return parent + field
+	}
+	return parent + "." + field
+}
@@ -0,0 +1,35 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrors(t *testing.T) {
+	nested := errors.ValidationErrors{}
+	nested.Add("spot", errors.ErrInvalidSpotPrice)
+	nested.Add("dividends[0]", errors.ErrInvalidDividend)
+	nested.Add("volatility", nil)
+
+	errs := errors.ValidationErrors{}
+	errs.AddLeg(1, errors.ValidationErrors{{Field: "bid", Err: errors.ErrInvalidBidPrice}})
+	errs.AddLeg(2, errors.ErrInvalidOptionsType)
+	errs.Add("market", nested)
+
+	assert.Len(t, errs, 4)
+	assert.Equal(t, "contracts[1].bid: invalid bid price; contracts[2]: invalid option type; market.spot: invalid spot price; market.dividends[0]: invalid dividend", errs.Error())
+	assert.ErrorIs(t, errs, errors.ErrInvalidDividend)
+	assert.ErrorIs(t, fmt.Errorf("wrapped: %w", errs), errors.ErrInvalidBidPrice)
+	assert.NotErrorIs(t, errs, errors.ErrInvalidAskPrice)
+
+	assert.NoError(t, errors.ValidationErrors{}.Err())
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "ask_bid_mismatch", errors.Code(errors.ErrAskBidMismatch))
+	assert.Equal(t, "invalid_number_of_contracts", errors.Code(fmt.Errorf("%w: got 0", errors.ErrInvalidNumberOfContracts)))
+	assert.Equal(t, "invalid_request", errors.Code(fmt.Errorf("unknown")))
+}
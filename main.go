@@ -1,13 +1,67 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"net"
 
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/pricing"
 	"github.com/aries-financial-inc/options-service/routes"
+	"github.com/aries-financial-inc/options-service/rpc"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	flag.IntVar(&controllers.MaxOptionsContracts, "max-contracts", controllers.MaxOptionsContracts, "maximum number of options contracts accepted for analysis")
+	flag.IntVar(&pricing.BinomialSteps, "binomial-steps", pricing.BinomialSteps, "number of steps of the binomial trees pricing American options")
+	flag.IntVar(&controllers.MaxAnalysisNodes, "max-analysis-nodes", controllers.MaxAnalysisNodes, "budget of binomial tree nodes for the American options of an analysis")
+	flag.Int64Var(&routes.MaxRequestBytes, "max-request-bytes", routes.MaxRequestBytes, "maximum size of the body of a request, in bytes")
+	flag.IntVar(&controllers.MaxBatchSize, "max-batch-size", controllers.MaxBatchSize, "maximum number of analyses in a batch")
+	flag.IntVar(&controllers.MaxBatchNodes, "max-batch-nodes", controllers.MaxBatchNodes, "budget of binomial tree nodes for the American options of all the analyses of a batch")
+	flag.IntVar(&controllers.BatchWorkers, "batch-workers", controllers.BatchWorkers, "number of analyses of a batch run concurrently")
+	grpcPort := flag.Int("grpc-port", 9090, "port of the gRPC server")
+	flag.Parse()
+
+	if controllers.MaxOptionsContracts < 1 {
+		log.Fatalf("max-contracts must be at least 1, got %d", controllers.MaxOptionsContracts)
+	}
+
+	if pricing.BinomialSteps < 1 {
+		log.Fatalf("binomial-steps must be at least 1, got %d", pricing.BinomialSteps)
+	}
+
+	if controllers.MaxAnalysisNodes < 1 {
+		log.Fatalf("max-analysis-nodes must be at least 1, got %d", controllers.MaxAnalysisNodes)
+	}
+
+	if routes.MaxRequestBytes < 1 {
+		log.Fatalf("max-request-bytes must be at least 1, got %d", routes.MaxRequestBytes)
+	}
+
+	if controllers.MaxBatchSize < 1 {
+		log.Fatalf("max-batch-size must be at least 1, got %d", controllers.MaxBatchSize)
+	}
+
+	if controllers.MaxBatchNodes < 1 {
+		log.Fatalf("max-batch-nodes must be at least 1, got %d", controllers.MaxBatchNodes)
+	}
+
+	if controllers.BatchWorkers < 1 {
+		log.Fatalf("batch-workers must be at least 1, got %d", controllers.BatchWorkers)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
+	if err != nil {
+		log.Fatalf("listening on gRPC port %d: %v", *grpcPort, err)
+	}
+	go func() {
+		fmt.Printf("gRPC listening on port %d...\n", *grpcPort)
+		log.Fatal(rpc.NewServer(grpc.MaxRecvMsgSize(int(routes.MaxRequestBytes))).Serve(listener))
+	}()
+
 	fmt.Println("listening on port 8080...")
 	router := routes.SetupRouter()
 	router.Run() // listen and serve on 0.0.0.0:8080
-}
\ No newline at end of file
+}
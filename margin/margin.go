@@ -0,0 +1,229 @@
+// estimates of the initial margin of a position, following Reg-T style rules
+package margin
+
+import (
+	"fmt"
+	"math"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+const (
+	// a naked short option needs 20% of the underlying, less the amount it is out of the money
+	NakedRate = 0.2
+	// and at least 10% of the underlying for calls, or of the strike for puts
+	NakedMinimumRate = 0.1
+	// stock is bought, or sold short, on 50% margin
+	StockRate = 0.5
+)
+
+// rules of the requirements
+const (
+	COVERED     = "covered"
+	SPREAD      = "spread"
+	IRON_CONDOR = "iron-condor"
+	NAKED       = "naked"
+	STOCK       = "stock"
+	PREMIUM     = "premium"
+)
+
+// Margin represents the initial margin of a position and the requirements it adds up.
+// the requirement is never negative. the credits of short options count towards it
+type Margin struct {
+	Requirement  float64       `json:"requirement"`
+	Requirements []Requirement `json:"requirements"`
+}
+
+// Requirement represents the margin of some legs, by their indexes in the contracts, under a rule
+type Requirement struct {
+	Rule   string  `json:"rule"`
+	Legs   []int   `json:"legs"`
+	Amount float64 `json:"amount"`
+}
+
+// a contract, and its shares not yet paired with other legs
+type leg struct {
+	index    int
+	contract options.OptionsContract
+	shares   int
+}
+
+// Calculate estimates the initial margin of the contracts at the spot price of the underlying.
+// short options are covered by stock first, then paired with long options into spreads. the rest are naked.
+// the spot price is needed for naked options and stock. it is zero when it is not known
+func Calculate(contracts []options.OptionsContract, spot float64) (Margin, error) {
+	legs := []*leg{}
+	for i, c := range contracts {
+		legs = append(legs, &leg{i, c, c.Shares()})
+	}
+
+	requirements := cover(legs)
+	requirements = append(requirements, ironCondors(spreads(legs))...)
+
+	for _, l := range legs {
+		if l.shares == 0 || l.isLong() {
+			continue
+		}
+		if spot <= 0 {
+			return Margin{}, fmt.Errorf("%w: naked options need the spot price", appErrors.ErrInvalidSpotPrice)
+		}
+		requirements = append(requirements, Requirement{NAKED, []int{l.index}, naked(l.contract, spot) * float64(l.shares)})
+	}
+
+	premium := Requirement{Rule: PREMIUM}
+	for _, l := range legs {
+		c := l.contract
+		if c.OptionsType.Value() == options.STOCK {
+			if spot <= 0 {
+				return Margin{}, fmt.Errorf("%w: stock needs the spot price", appErrors.ErrInvalidSpotPrice)
+			}
+			requirements = append(requirements, Requirement{STOCK, []int{l.index}, StockRate * spot * float64(c.Shares())})
+			continue
+		}
+		// debits are paid in full, and credits count towards the requirement
+		premium.Legs = append(premium.Legs, l.index)
+		premium.Amount += c.LongShort.Sign() * c.Premium() * float64(c.Shares())
+	}
+	if len(premium.Legs) > 0 {
+		requirements = append(requirements, premium)
+	}
+
+	m := Margin{Requirements: requirements}
+	for i := range m.Requirements {
+		m.Requirements[i].Amount = roundToCents(m.Requirements[i].Amount)
+		m.Requirement += m.Requirements[i].Amount
+	}
+	m.Requirement = roundToCents(math.Max(0, m.Requirement))
+	return m, nil
+}
+
+// short calls covered by long stock, and short puts covered by short stock, need no margin of their own
+func cover(legs []*leg) []Requirement {
+	requirements := []Requirement{}
+	for _, short := range legs {
+		if short.isLong() || short.contract.OptionsType.Value() == options.STOCK {
+			continue
+		}
+		for _, stock := range legs {
+			if stock.contract.OptionsType.Value() != options.STOCK || stock.shares == 0 {
+				continue
+			}
+			covers := stock.isLong() == (short.contract.OptionsType.Value() == options.CALL)
+			if !covers || short.shares == 0 {
+				continue
+			}
+			shares := min(short.shares, stock.shares)
+			short.shares -= shares
+			stock.shares -= shares
+			requirements = append(requirements, Requirement{COVERED, []int{stock.index, short.index}, 0})
+		}
+	}
+	return requirements
+}
+
+// a spread of a short and a long option of the same type, where the long option expires no sooner,
+// needs the amount its strikes are apart, if the short option is the one in the money first
+type spread struct {
+	Requirement
+	optionsType options.OptionsType
+	shares      int
+	width       float64
+}
+
+func spreads(legs []*leg) []spread {
+	spreads := []spread{}
+	for _, short := range legs {
+		if short.isLong() || short.contract.OptionsType.Value() == options.STOCK {
+			continue
+		}
+		for short.shares > 0 {
+			long, width := bestLong(short, legs)
+			if long == nil {
+				break
+			}
+			shares := min(short.shares, long.shares)
+			short.shares -= shares
+			long.shares -= shares
+			spreads = append(spreads, spread{
+				Requirement: Requirement{SPREAD, []int{short.index, long.index}, width * float64(shares)},
+				optionsType: short.contract.OptionsType.Value(),
+				shares:      shares,
+				width:       width,
+			})
+		}
+	}
+	return spreads
+}
+
+// the long option that spreads the short option with the least margin
+func bestLong(short *leg, legs []*leg) (*leg, float64) {
+	var best *leg
+	bestWidth := math.Inf(1)
+	for _, l := range legs {
+		c := l.contract
+		if !l.isLong() || l.shares == 0 || c.OptionsType.Value() != short.contract.OptionsType.Value() {
+			continue
+		}
+		if c.ExpirationDate.Before(short.contract.ExpirationDate) {
+			continue
+		}
+
+		width := math.Max(0, c.StrikePrice-short.contract.StrikePrice)
+		if c.OptionsType.Value() == options.PUT {
+			width = math.Max(0, short.contract.StrikePrice-c.StrikePrice)
+		}
+		if width < bestWidth {
+			best, bestWidth = l, width
+		}
+	}
+	return best, bestWidth
+}
+
+// a put spread and a call spread on the same shares cannot both lose. they need the margin of the wider one
+func ironCondors(spreads []spread) []Requirement {
+	requirements := []Requirement{}
+	paired := make([]bool, len(spreads))
+	for i, put := range spreads {
+		if put.optionsType != options.PUT || put.width == 0 {
+			continue
+		}
+		for j, call := range spreads {
+			if paired[j] || call.optionsType != options.CALL || call.width == 0 || call.shares != put.shares {
+				continue
+			}
+			paired[i], paired[j] = true, true
+			requirements = append(requirements, Requirement{
+				IRON_CONDOR,
+				append(put.Legs, call.Legs...),
+				math.Max(put.Amount, call.Amount),
+			})
+			break
+		}
+	}
+
+	for i, s := range spreads {
+		if !paired[i] {
+			requirements = append(requirements, s.Requirement)
+		}
+	}
+	return requirements
+}
+
+// the margin of a naked short option per share, including its premium
+func naked(c options.OptionsContract, spot float64) float64 {
+	if c.OptionsType.Value() == options.CALL {
+		outOfTheMoney := math.Max(0, c.StrikePrice-spot)
+		return math.Max(NakedRate*spot-outOfTheMoney, NakedMinimumRate*spot) + c.Premium()
+	}
+	outOfTheMoney := math.Max(0, spot-c.StrikePrice)
+	return math.Max(NakedRate*spot-outOfTheMoney, NakedMinimumRate*c.StrikePrice) + c.Premium()
+}
+
+func (l *leg) isLong() bool {
+	return l.contract.LongShort.Value() == options.LONG
+}
+
+func roundToCents(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,125 @@
+package margin_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/margin"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/stretchr/testify/assert"
+)
+
+var expirationDate = time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC)
+
+func option(optionsType options.OptionsType, longShort options.LongShort, strike, bid, ask float64) options.OptionsContract {
+	return options.OptionsContract{
+		OptionsType:    optionsType,
+		LongShort:      longShort,
+		StrikePrice:    strike,
+		Bid:            bid,
+		Ask:            ask,
+		ExpirationDate: expirationDate,
+	}
+}
+
+func TestNakedOptions(t *testing.T) {
+	// 20% of 100, less 10 out of the money, is below the minimum of 10% of 100
+	m, err := margin.Calculate([]options.OptionsContract{option("call", "short", 110, 2, 2.2)}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, margin.Margin{
+		Requirement: 1000,
+		Requirements: []margin.Requirement{
+			{Rule: margin.NAKED, Legs: []int{0}, Amount: 1200},
+			{Rule: margin.PREMIUM, Legs: []int{0}, Amount: -200},
+		},
+	}, m)
+
+	// 20% of 100, in the money
+	m, err = margin.Calculate([]options.OptionsContract{option("put", "short", 105, 7, 7.4)}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2000.0, m.Requirement)
+
+	// 10% of the strike
+	m, err = margin.Calculate([]options.OptionsContract{option("put", "short", 80, 0.5, 0.6)}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 800.0, m.Requirement)
+
+	_, err = margin.Calculate([]options.OptionsContract{option("put", "short", 80, 0.5, 0.6)}, 0)
+	assert.ErrorIs(t, err, errors.ErrInvalidSpotPrice)
+}
+
+func TestSpreads(t *testing.T) {
+	// a credit spread needs its width, less the credit
+	m, err := margin.Calculate([]options.OptionsContract{
+		option("call", "short", 100, 5, 5.2),
+		option("call", "long", 110, 1, 1.2),
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, margin.Margin{
+		Requirement: 620,
+		Requirements: []margin.Requirement{
+			{Rule: margin.SPREAD, Legs: []int{0, 1}, Amount: 1000},
+			{Rule: margin.PREMIUM, Legs: []int{0, 1}, Amount: -380},
+		},
+	}, m)
+
+	// a debit spread is paid in full
+	m, err = margin.Calculate([]options.OptionsContract{
+		option("put", "short", 90, 1, 1.2),
+		option("put", "long", 100, 5, 5.2),
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 420.0, m.Requirement)
+
+	// the long option expires before the short option. it does not cover it
+	long := option("call", "long", 110, 1, 1.2)
+	long.ExpirationDate = expirationDate.AddDate(0, -1, 0)
+	m, err = margin.Calculate([]options.OptionsContract{option("call", "short", 100, 5, 5.2), long}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, margin.NAKED, m.Requirements[0].Rule)
+}
+
+func TestIronCondor(t *testing.T) {
+	m, err := margin.Calculate([]options.OptionsContract{
+		option("put", "long", 85, 1, 1.2),
+		option("put", "short", 95, 2, 2.2),
+		option("call", "short", 105, 2.1, 2.3),
+		option("call", "long", 120, 1.1, 1.3),
+	}, 100)
+	assert.NoError(t, err)
+	// the wider call spread, less a credit of 1.6
+	assert.Equal(t, margin.Margin{
+		Requirement: 1340,
+		Requirements: []margin.Requirement{
+			{Rule: margin.IRON_CONDOR, Legs: []int{1, 0, 2, 3}, Amount: 1500},
+			{Rule: margin.PREMIUM, Legs: []int{0, 1, 2, 3}, Amount: -160},
+		},
+	}, m)
+}
+
+func TestCoveredCall(t *testing.T) {
+	m, err := margin.Calculate([]options.OptionsContract{
+		{OptionsType: "stock", LongShort: "long", EntryPrice: 95, Quantity: 200},
+		option("call", "short", 110, 2, 2.2),
+	}, 100)
+	assert.NoError(t, err)
+	// half the stock, less the credit. the call is covered by 100 of the 200 shares
+	assert.Equal(t, margin.Margin{
+		Requirement: 9800,
+		Requirements: []margin.Requirement{
+			{Rule: margin.COVERED, Legs: []int{0, 1}, Amount: 0},
+			{Rule: margin.STOCK, Legs: []int{0}, Amount: 10000},
+			{Rule: margin.PREMIUM, Legs: []int{1}, Amount: -200},
+		},
+	}, m)
+
+	_, err = margin.Calculate([]options.OptionsContract{{OptionsType: "stock", LongShort: "long", EntryPrice: 95}}, 0)
+	assert.ErrorIs(t, err, errors.ErrInvalidSpotPrice)
+}
+
+func TestLongOptions(t *testing.T) {
+	m, err := margin.Calculate([]options.OptionsContract{option("call", "long", 100, 10.05, 12.04)}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1204.0, m.Requirement)
+}
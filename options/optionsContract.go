@@ -2,6 +2,8 @@
 package options
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -15,12 +17,14 @@ type OptionsType string
 const (
 	CALL OptionsType = "call"
 	PUT  OptionsType = "put"
+	// shares of the underlying itself. for covered calls, protective puts and collars
+	STOCK OptionsType = "stock"
 )
 
 // values are case insensitive
 func (o OptionsType) IsValid() error {
 	switch (OptionsType)(strings.ToLower((string)(o))) {
-	case CALL, PUT:
+	case CALL, PUT, STOCK:
 	default:
 		return appErrors.ErrInvalidOptionsType
 	}
@@ -31,6 +35,31 @@ func (o OptionsType) Value() OptionsType {
 	return (OptionsType)(strings.ToLower((string)(o)))
 }
 
+// European options are exercised at expiry only. American options at any time until expiry
+type ExerciseStyle string
+
+const (
+	EUROPEAN ExerciseStyle = "european"
+	AMERICAN ExerciseStyle = "american"
+)
+
+// values are case insensitive. options are European unless specified otherwise
+func (e ExerciseStyle) IsValid() error {
+	switch e.Value() {
+	case EUROPEAN, AMERICAN:
+	default:
+		return appErrors.ErrInvalidExerciseStyle
+	}
+	return nil
+}
+
+func (e ExerciseStyle) Value() ExerciseStyle {
+	if e == "" {
+		return EUROPEAN
+	}
+	return (ExerciseStyle)(strings.ToLower((string)(e)))
+}
+
 // TODO: not a readable name. being consistent with the contract.
 // maybe name it position along with contract change
 // do not introduce new terminology
@@ -54,6 +83,28 @@ func (o LongShort) Value() LongShort {
 	return (LongShort)(strings.ToLower((string)(o)))
 }
 
+// 1 for long and -1 for short positions
+func (o LongShort) Sign() float64 {
+	if o.Value() == SHORT {
+		return -1
+	}
+	return 1
+}
+
+// a contract is for 100 shares of the underlying, unless specified otherwise
+const DefaultMultiplier = 100
+
+// a stock leg is for individual shares
+const DefaultStockMultiplier = 1
+
+// MaxShares is the maximum number of shares of the underlying of a leg, its quantity times its multiplier.
+// it keeps the profits and losses far from overflows
+const MaxShares = 10_000_000
+
+// MaxPrice is the maximum strike price, bid, ask and fill price of a contract, and entry price of a stock leg. it is the maximum spot price of the market.
+// higher prices are not quotes, and overflow the payoffs
+const MaxPrice = 1e9
+
 type OptionsContract struct {
 	// cannot name the variable "type"
 	OptionsType    OptionsType `json:"type"`
@@ -62,80 +113,207 @@ type OptionsContract struct {
 	Ask            float64     `json:"ask"`
 	ExpirationDate time.Time   `json:"expiration_date"`
 	LongShort      LongShort   `json:"long_short"`
+	// number of contracts. long_short decides the direction, so it is never negative. defaults to 1
+	Quantity int `json:"quantity,omitempty"`
+	// number of shares of the underlying per contract. defaults to DefaultMultiplier
+	Multiplier int `json:"multiplier,omitempty"`
+	// price at which the shares of a stock leg are bought or sold. options use bid and ask instead
+	EntryPrice float64 `json:"entry_price,omitempty"`
+	// defaults to EUROPEAN
+	ExerciseStyle ExerciseStyle `json:"exercise_style,omitempty"`
+	// price at which the option is bought or sold, in place of the ask or the bid
+	FillPrice float64 `json:"fill_price,omitempty"`
 }
 
+// the first error of the contract
 func (o OptionsContract) IsValid() error {
-	if err := o.OptionsType.IsValid(); err != nil {
-		return err
+	if errs := o.Validate(); len(errs) > 0 {
+		return errs[0].Err
 	}
+	return nil
+}
 
-	if o.StrikePrice <= 0 {
-		return appErrors.ErrInvalidStrikePrice
-	}
+// Validate returns all the errors of the contract, by field.
+// the comparisons reject NaN, which JSON cannot carry but protobuf can
+func (o OptionsContract) Validate() appErrors.ValidationErrors {
+	errs := appErrors.ValidationErrors{}
+	errs.Add("type", o.OptionsType.IsValid())
 
-	if o.Bid <= 0 {
-		return appErrors.ErrInvalidBidPrice
+	if o.OptionsType.Value() == STOCK {
+		o.validateStock(&errs)
+		return errs
 	}
 
-	if o.Ask <= 0 {
-		return appErrors.ErrInvalidAskPrice
-	}
+	validatePrice(&errs, "strike_price", o.StrikePrice, appErrors.ErrInvalidStrikePrice)
+	validatePrice(&errs, "bid", o.Bid, appErrors.ErrInvalidBidPrice)
+	validatePrice(&errs, "ask", o.Ask, appErrors.ErrInvalidAskPrice)
 
 	if o.Ask < o.Bid {
-		return appErrors.ErrAskBidMismatch
+		errs.Add("ask", appErrors.ErrAskBidMismatch)
 	}
 
-	if err := o.LongShort.IsValid(); err != nil {
-		return err
+	errs.Add("long_short", o.LongShort.IsValid())
+
+	o.validateShares(&errs)
+
+	errs.Add("exercise_style", o.ExerciseStyle.IsValid())
+
+	// zero is no fill price
+	if o.FillPrice != 0 {
+		validatePrice(&errs, "fill_price", o.FillPrice, appErrors.ErrInvalidFillPrice)
 	}
 
 	if o.ExpirationDate.IsZero() || o.ExpirationDate.Before(time.Now()) {
-		return appErrors.ErrInvalidExpirationDate
+		errs.Add("expiration_date", appErrors.ErrInvalidExpirationDate)
 	}
 
-	return nil
+	return errs
+}
+
+// a stock leg has no strike price, bid, ask or expiration date
+func (o OptionsContract) validateStock(errs *appErrors.ValidationErrors) {
+	validatePrice(errs, "entry_price", o.EntryPrice, appErrors.ErrInvalidEntryPrice)
+
+	errs.Add("long_short", o.LongShort.IsValid())
+
+	o.validateShares(errs)
+}
+
+// a price is positive and at most MaxPrice
+func validatePrice(errs *appErrors.ValidationErrors, field string, price float64, err error) {
+	if !(price > 0) {
+		errs.Add(field, err)
+	} else if price > MaxPrice {
+		errs.Add(field, fmt.Errorf("%w: expected at most %g", err, float64(MaxPrice)))
+	}
+}
+
+// quantity and multiplier are not negative, and all contracts are for at most MaxShares shares
+func (o OptionsContract) validateShares(errs *appErrors.ValidationErrors) {
+	valid := true
+	if o.Quantity < 0 || o.Quantity > MaxShares {
+		errs.Add("quantity", appErrors.ErrInvalidQuantity)
+		valid = false
+	}
+
+	if o.Multiplier < 0 || o.Multiplier > MaxShares {
+		errs.Add("multiplier", appErrors.ErrInvalidMultiplier)
+		valid = false
+	}
+
+	// both are bounded. their product does not overflow
+	if valid && o.Shares() > MaxShares {
+		errs.Add("quantity", fmt.Errorf("%w: %d shares, expected at most %d", appErrors.ErrInvalidQuantity, o.Shares(), MaxShares))
+	}
+}
+
+// the underlying price at which the payoff changes its slope.
+// the payoff of a stock leg has no kink. its entry price is used instead
+func (o OptionsContract) ReferencePrice() float64 {
+	if o.OptionsType.Value() == STOCK {
+		return o.EntryPrice
+	}
+	return o.StrikePrice
 }
 
 func (o OptionsContract) CalculateBreakEvenPoint() float64 {
-	// long call
-	if o.LongShort == LONG.Value() && o.OptionsType.Value() == CALL {
-		return precisionTotwodecimalPlaces(o.StrikePrice + o.Ask)
+	// long or short stock
+	if o.OptionsType.Value() == STOCK {
+		return precisionTotwodecimalPlaces(o.EntryPrice)
 	}
-	// short call
-	if o.LongShort == SHORT.Value() && o.OptionsType.Value() == CALL {
-		return precisionTotwodecimalPlaces(o.StrikePrice + o.Bid)
+	if o.LongShort.IsValid() != nil {
+		return 0.0
 	}
-	// long put
-	if o.LongShort == LONG.Value() && o.OptionsType.Value() == PUT {
-		return precisionTotwodecimalPlaces(o.StrikePrice - o.Ask)
+	// long or short call
+	if o.OptionsType.Value() == CALL {
+		return precisionTotwodecimalPlaces(o.StrikePrice + o.Premium())
 	}
-	// short put
-	if o.LongShort == SHORT.Value() && o.OptionsType.Value() == PUT {
-		return precisionTotwodecimalPlaces(o.StrikePrice - o.Bid)
+	// long or short put
+	if o.OptionsType.Value() == PUT {
+		return precisionTotwodecimalPlaces(o.StrikePrice - o.Premium())
 	}
 	return 0.0
 }
 
+// price paid for a share of a long contract, or received for a share of a short contract.
+// longs fill at the ask and shorts at the bid, unless a fill price is given. stock legs fill at their entry price
+func (o OptionsContract) Premium() float64 {
+	if o.OptionsType.Value() == STOCK {
+		return o.EntryPrice
+	}
+	if o.FillPrice > 0 {
+		return o.FillPrice
+	}
+	if o.LongShort.Value() == SHORT {
+		return o.Bid
+	}
+	return o.Ask
+}
+
+// the number of shares of the underlying, for all contracts
+func (o OptionsContract) Shares() int {
+	quantity := o.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	multiplier := o.Multiplier
+	if multiplier == 0 && o.OptionsType.Value() == STOCK {
+		multiplier = DefaultStockMultiplier
+	} else if multiplier == 0 {
+		multiplier = DefaultMultiplier
+	}
+
+	return quantity * multiplier
+}
+
+// profit or loss of all contracts at the underlying price at expiry. it is rounded once, for all shares
 func (o OptionsContract) CalculateProfitOrLoss(price float64) float64 {
+	return precisionTotwodecimalPlaces(o.calculateProfitOrLossPerShare(price) * float64(o.Shares()))
+}
+
+func (o OptionsContract) calculateProfitOrLossPerShare(price float64) float64 {
 	// long call
 	if o.LongShort.Value() == LONG && o.OptionsType.Value() == CALL {
-		return precisionTotwodecimalPlaces(maxFloat64(0, price-o.StrikePrice) - o.Ask)
+		return maxFloat64(0, price-o.StrikePrice) - o.Premium()
 	}
 	// short call
 	if o.LongShort.Value() == SHORT && o.OptionsType.Value() == CALL {
-		return precisionTotwodecimalPlaces(o.Bid - maxFloat64(0, price-o.StrikePrice))
+		return o.Premium() - maxFloat64(0, price-o.StrikePrice)
 	}
 	// long put
 	if o.LongShort.Value() == LONG && o.OptionsType.Value() == PUT {
-		return precisionTotwodecimalPlaces(maxFloat64(o.StrikePrice-price, 0) - o.Ask)
+		return maxFloat64(o.StrikePrice-price, 0) - o.Premium()
 	}
 	// short put
 	if o.LongShort.Value() == SHORT && o.OptionsType.Value() == PUT {
-		return precisionTotwodecimalPlaces(o.Bid - max(0, o.StrikePrice-price))
+		return o.Premium() - max(0, o.StrikePrice-price)
+	}
+	// long stock
+	if o.LongShort.Value() == LONG && o.OptionsType.Value() == STOCK {
+		return price - o.EntryPrice
+	}
+	// short stock
+	if o.LongShort.Value() == SHORT && o.OptionsType.Value() == STOCK {
+		return o.EntryPrice - price
 	}
 	return 0.0
 }
 
+// the first of the expiration dates of all options. stock legs have no expiration date
+func NearestExpirationDate(contracts []OptionsContract) (time.Time, bool) {
+	nearest := time.Time{}
+	for _, c := range contracts {
+		if c.OptionsType.Value() == STOCK {
+			continue
+		}
+		if nearest.IsZero() || c.ExpirationDate.Before(nearest) {
+			nearest = c.ExpirationDate
+		}
+	}
+	return nearest, !nearest.IsZero()
+}
+
 // TODO: move to separate utils
 func maxFloat64(a, b float64) float64 {
 	if a > b {
@@ -146,6 +324,7 @@ func maxFloat64(a, b float64) float64 {
 }
 
 // a simple and naive solution, assuming prices are not too large
+// rounds to the nearest cent. truncating turns -9.539999... into -9.53
 func precisionTotwodecimalPlaces(f float64) float64 {
-	return (float64(int(f * 100))) / 100
+	return math.Round(f*100) / 100
 }
@@ -1,8 +1,11 @@
 package options_test
 
 import (
+	"math"
 	"testing"
+	"time"
 
+	"github.com/aries-financial-inc/options-service/errors"
 	"github.com/aries-financial-inc/options-service/options"
 	"github.com/stretchr/testify/assert"
 )
@@ -31,6 +34,16 @@ func TestLongShortIsCaseInsensitive(t *testing.T) {
 	assert.Equal(t, options.SHORT, (options.LongShort)("SHORT").Value())
 	assert.Equal(t, options.SHORT, (options.LongShort)("sHort").Value())
 }
+func TestExerciseStyle(t *testing.T) {
+	assert.Equal(t, options.EUROPEAN, (options.ExerciseStyle)("").Value())
+	assert.Equal(t, options.EUROPEAN, (options.ExerciseStyle)("European").Value())
+	assert.Equal(t, options.AMERICAN, (options.ExerciseStyle)("AMERICAN").Value())
+
+	assert.NoError(t, (options.ExerciseStyle)("").IsValid())
+	assert.NoError(t, (options.ExerciseStyle)("American").IsValid())
+	assert.ErrorIs(t, (options.ExerciseStyle)("bermudan").IsValid(), errors.ErrInvalidExerciseStyle)
+}
+
 func TestCalculateBreakEvenPoint(t *testing.T) {
 	// strike + ask
 	assert.Equal(t, 116.5, options.OptionsContract{
@@ -73,7 +86,7 @@ func TestCalculateBreakEvenPoint(t *testing.T) {
 func TestCalculateProfitOrLoss(t *testing.T){
 	
 	underlyingPrice := 120.0
-	assert.Equal(t, 3.5, options.OptionsContract{
+	assert.Equal(t, 350.0, options.OptionsContract{
 		LongShort:   options.LONG,
 		OptionsType: options.CALL,
 		StrikePrice: 102.5,
@@ -81,7 +94,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, -5.4, options.OptionsContract{
+	assert.Equal(t, -540.0, options.OptionsContract{
 		LongShort:   options.SHORT,
 		OptionsType: options.CALL,
 		StrikePrice: 102.5,
@@ -89,7 +102,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, -14.0, options.OptionsContract{
+	assert.Equal(t, -1400.0, options.OptionsContract{
 		LongShort:   options.LONG,
 		OptionsType: options.PUT,
 		StrikePrice: 102.5,
@@ -97,7 +110,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, 12.1, options.OptionsContract{
+	assert.Equal(t, 1210.0, options.OptionsContract{
 		LongShort:   options.SHORT,
 		OptionsType: options.PUT,
 		StrikePrice: 102.5,
@@ -107,7 +120,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 
 
 	underlyingPrice = 90.0
-	assert.Equal(t, -14.0, options.OptionsContract{
+	assert.Equal(t, -1400.0, options.OptionsContract{
 		LongShort:   options.LONG,
 		OptionsType: options.CALL,
 		StrikePrice: 102.5,
@@ -115,7 +128,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, 12.1, options.OptionsContract{
+	assert.Equal(t, 1210.0, options.OptionsContract{
 		LongShort:   options.SHORT,
 		OptionsType: options.CALL,
 		StrikePrice: 102.5,
@@ -123,7 +136,7 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, -1.5, options.OptionsContract{
+	assert.Equal(t, -150.0, options.OptionsContract{
 		LongShort:   options.LONG,
 		OptionsType: options.PUT,
 		StrikePrice: 102.5,
@@ -131,11 +144,127 @@ func TestCalculateProfitOrLoss(t *testing.T){
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
 
-	assert.Equal(t, -0.4, options.OptionsContract{
+	assert.Equal(t, -40.0, options.OptionsContract{
 		LongShort:   options.SHORT,
 		OptionsType: options.PUT,
 		StrikePrice: 102.5,
 		Ask:         14.00,
 		Bid:         12.10,
 	}.CalculateProfitOrLoss(underlyingPrice))
-}
\ No newline at end of file
+}
+
+func TestCalculateProfitOrLossOfQuantityAndMultiplier(t *testing.T) {
+	contract := options.OptionsContract{
+		LongShort:   options.LONG,
+		OptionsType: options.CALL,
+		StrikePrice: 102.5,
+		Ask:         14.00,
+		Bid:         12.10,
+	}
+	assert.Equal(t, 100, contract.Shares())
+
+	contract.Quantity = 10
+	assert.Equal(t, 1000, contract.Shares())
+	assert.Equal(t, 3500.0, contract.CalculateProfitOrLoss(120))
+
+	// mini options
+	contract.Multiplier = 10
+	assert.Equal(t, 100, contract.Shares())
+	assert.Equal(t, 350.0, contract.CalculateProfitOrLoss(120))
+
+	// the break even point does not depend on the number of shares
+	assert.Equal(t, 116.5, contract.CalculateBreakEvenPoint())
+
+	// filled at the mid of half a cent. the profit or loss of all shares is rounded, not the one of a share
+	contract.Multiplier = 0
+	contract.FillPrice = 5.075
+	assert.Equal(t, -5075.0, contract.CalculateProfitOrLoss(100))
+	assert.Equal(t, 5.0, contract.CalculateProfitOrLoss(107.58))
+}
+
+func TestStock(t *testing.T) {
+	assert.Equal(t, options.STOCK, (options.OptionsType)("Stock").Value())
+
+	long := options.OptionsContract{
+		LongShort:   options.LONG,
+		OptionsType: options.STOCK,
+		EntryPrice:  100,
+	}
+	assert.Equal(t, 1, long.Shares())
+	assert.Equal(t, 100.0, long.ReferencePrice())
+	assert.Equal(t, 100.0, long.CalculateBreakEvenPoint())
+	assert.Equal(t, 20.0, long.CalculateProfitOrLoss(120))
+
+	short := options.OptionsContract{
+		LongShort:   options.SHORT,
+		OptionsType: options.STOCK,
+		EntryPrice:  100,
+		Quantity:    50,
+	}
+	assert.Equal(t, 50, short.Shares())
+	assert.Equal(t, -1000.0, short.CalculateProfitOrLoss(120))
+	assert.Equal(t, 500.0, short.CalculateProfitOrLoss(90))
+}
+
+func TestValidateShares(t *testing.T) {
+	contract := options.OptionsContract{
+		LongShort:      options.LONG,
+		OptionsType:    options.CALL,
+		StrikePrice:    100,
+		Ask:            12.04,
+		Bid:            10.05,
+		ExpirationDate: time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC),
+		Quantity:       options.MaxShares / options.DefaultMultiplier,
+	}
+	assert.NoError(t, contract.IsValid())
+
+	// the product of quantity and multiplier would overflow
+	contract.Quantity = math.MaxInt
+	assert.Equal(t, []string{"quantity"}, fields(contract.Validate()))
+	assert.ErrorIs(t, contract.IsValid(), errors.ErrInvalidQuantity)
+
+	contract.Quantity = 1000
+	contract.Multiplier = math.MaxInt
+	assert.Equal(t, []string{"multiplier"}, fields(contract.Validate()))
+
+	// both are bounded, and so is their product
+	contract.Multiplier = 100_000
+	assert.Equal(t, []string{"quantity"}, fields(contract.Validate()))
+
+	stock := options.OptionsContract{LongShort: options.LONG, OptionsType: options.STOCK, EntryPrice: 100, Quantity: options.MaxShares + 1}
+	assert.ErrorIs(t, stock.IsValid(), errors.ErrInvalidQuantity)
+}
+
+func TestValidatePrices(t *testing.T) {
+	contract := options.OptionsContract{
+		LongShort:      options.LONG,
+		OptionsType:    options.CALL,
+		StrikePrice:    options.MaxPrice,
+		Ask:            options.MaxPrice,
+		Bid:            options.MaxPrice,
+		FillPrice:      options.MaxPrice,
+		ExpirationDate: time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(t, contract.IsValid())
+
+	// twice the strike price overflows
+	contract.StrikePrice = 1e308
+	contract.Ask = math.Inf(1)
+	contract.Bid = math.NaN()
+	contract.FillPrice = -1
+	assert.Equal(t, []string{"strike_price", "bid", "ask", "fill_price"}, fields(contract.Validate()))
+	assert.ErrorIs(t, contract.IsValid(), errors.ErrInvalidStrikePrice)
+
+	stock := options.OptionsContract{LongShort: options.LONG, OptionsType: options.STOCK, EntryPrice: options.MaxPrice}
+	assert.NoError(t, stock.IsValid())
+	stock.EntryPrice = 1e308
+	assert.ErrorIs(t, stock.IsValid(), errors.ErrInvalidEntryPrice)
+}
+
+func fields(errs errors.ValidationErrors) []string {
+	fields := []string{}
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
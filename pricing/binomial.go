@@ -0,0 +1,139 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// BinomialSteps is the number of steps of the binomial trees. it is configured at startup
+var BinomialSteps = 200
+
+// Cox-Ross-Rubinstein binomial value of a share of an American option, at the given time.
+// cash dividends follow the escrowed dividend model. the tree is built on the spot price without the dividends paid until expiry,
+// and the dividends still to be paid are added back to the price of the underlying when the option is exercised early
+func BinomialValue(c options.OptionsContract, m Market, now time.Time) float64 {
+	return binomialTree(c, m, now).value
+}
+
+// the value of the option, and its delta, gamma and theta from the nodes of the first two steps
+type binomialResult struct {
+	value, delta, gamma, theta float64
+}
+
+func binomialTree(c options.OptionsContract, m Market, now time.Time) binomialResult {
+	isCall := c.OptionsType.Value() == options.CALL
+	intrinsic := func(price float64) float64 {
+		if isCall {
+			return math.Max(0, price-c.StrikePrice)
+		}
+		return math.Max(0, c.StrikePrice-price)
+	}
+
+	years := YearsToExpiry(now, c.ExpirationDate)
+	escrowedSpot := m.dividendAdjustedSpot(now, c.ExpirationDate)
+
+	// expired, or without volatility. the option is worth the better of exercising now and at expiry
+	if years <= 0 || m.Volatility <= 0 {
+		result := binomialResult{value: intrinsic(m.Spot)}
+		if atExpiry := intrinsic(escrowedSpot*math.Exp((m.Rate-m.DividendYield)*years)) * math.Exp(-m.Rate*years); atExpiry > result.value {
+			result.value = atExpiry
+		}
+		if result.value > 0 && isCall {
+			result.delta = 1
+		} else if result.value > 0 {
+			result.delta = -1
+		}
+		return result
+	}
+
+	steps := BinomialSteps
+	if m.BinomialSteps > 0 {
+		steps = m.BinomialSteps
+	}
+	steps = max(steps, 2)
+	dt := years / float64(steps)
+	up := math.Exp(m.Volatility * math.Sqrt(dt))
+	down := 1 / up
+	discount := math.Exp(-m.Rate * dt)
+	probability := (math.Exp((m.Rate-m.DividendYield)*dt) - down) / (up - down)
+
+	// the present values of the dividends still to be paid at each step. they are the same for all nodes of the step
+	dividends := make([]float64, steps+1)
+	for step := range dividends {
+		at := now.Add(time.Duration(float64(c.ExpirationDate.Sub(now)) * float64(step) / float64(steps)))
+		dividends[step] = m.presentValueOfDividends(at, c.ExpirationDate)
+	}
+
+	// the price of the underlying at the i-th node, from the lowest, of the given step
+	price := func(step, i int) float64 {
+		return escrowedSpot*math.Pow(up, float64(2*i-step)) + dividends[step]
+	}
+
+	// the prices of the nodes of a step, from the lowest. each node is up twice from the one below it
+	upSquared := up * up
+	prices := make([]float64, steps+1)
+	stepPrices := func(step int) []float64 {
+		p := escrowedSpot * math.Pow(down, float64(step))
+		for i := 0; i <= step; i++ {
+			prices[i] = p + dividends[step]
+			p *= upSquared
+		}
+		return prices[:step+1]
+	}
+
+	// values at expiry. no dividends are left to be paid
+	values := make([]float64, steps+1)
+	for i, p := range stepPrices(steps) {
+		values[i] = intrinsic(p)
+	}
+
+	result := binomialResult{}
+	for step := steps - 1; step >= 0; step-- {
+		for i, p := range stepPrices(step) {
+			continuation := discount * (probability*values[i+1] + (1-probability)*values[i])
+			values[i] = math.Max(continuation, intrinsic(p))
+		}
+
+		switch step {
+		case 2:
+			deltaUp := (values[2] - values[1]) / (price(2, 2) - price(2, 1))
+			deltaDown := (values[1] - values[0]) / (price(2, 1) - price(2, 0))
+			result.gamma = (deltaUp - deltaDown) / ((price(2, 2) - price(2, 0)) / 2)
+			// the middle node of the second step is at the spot price again
+			result.theta = values[1]
+		case 1:
+			result.delta = (values[1] - values[0]) / (price(1, 1) - price(1, 0))
+		}
+	}
+
+	result.value = values[0]
+	result.theta = (result.theta - result.value) / (2 * dt * 365)
+	return result
+}
+
+// greeks of an American option. delta, gamma and theta are read off the binomial tree.
+// vega and rho are finite differences of the binomial values. in the same units as the Black-Scholes greeks
+func binomialGreeks(c options.OptionsContract, m Market, now time.Time) Greeks {
+	tree := binomialTree(c, m, now)
+	if YearsToExpiry(now, c.ExpirationDate) <= 0 || m.Volatility <= 0 {
+		return Greeks{Delta: tree.delta}
+	}
+
+	bumped := func(bump func(m *Market)) float64 {
+		b := m
+		bump(&b)
+		return BinomialValue(c, b, now)
+	}
+
+	// the volatility stays positive
+	volatilityDown := math.Min(0.01, m.Volatility/2)
+	return Greeks{
+		Delta: tree.delta,
+		Gamma: tree.gamma,
+		Theta: tree.theta,
+		Vega:  (bumped(func(m *Market) { m.Volatility += 0.01 }) - bumped(func(m *Market) { m.Volatility -= volatilityDown })) / (0.01 + volatilityDown) / 100,
+		Rho:   (bumped(func(m *Market) { m.Rate += 0.01 }) - bumped(func(m *Market) { m.Rate -= 0.01 })) / 2,
+	}
+}
@@ -0,0 +1,95 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinomialValue(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+	call := options.OptionsContract{OptionsType: "Call", StrikePrice: 100, ExpirationDate: now.AddDate(1, 0, 0), ExerciseStyle: "American"}
+	put := options.OptionsContract{OptionsType: "Put", StrikePrice: 100, ExpirationDate: now.AddDate(1, 0, 0), ExerciseStyle: "American"}
+
+	// an American call on a stock without dividends is never exercised early. it is worth the European call
+	assert.InDelta(t, 10.4506, pricing.TheoreticalValue(call, market, now), 0.02)
+
+	// the early exercise of the put is worth something
+	american := pricing.TheoreticalValue(put, market, now)
+	assert.InDelta(t, 6.09, american, 0.02)
+	assert.Greater(t, american, 5.5735)
+
+	// deep in the money, the put is exercised right away
+	assert.Equal(t, 40.0, pricing.TheoreticalValue(put, pricing.Market{Spot: 60, Rate: 0.05, Volatility: 0.2}, now))
+
+	// expired
+	assert.Equal(t, 0.0, pricing.TheoreticalValue(call, market, now.AddDate(2, 0, 0)))
+}
+
+func TestBinomialValueWithDividends(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2, Dividends: []pricing.Dividend{{Date: now.AddDate(0, 6, 0), Amount: 5}}}
+	european := options.OptionsContract{OptionsType: "Call", StrikePrice: 90, ExpirationDate: now.AddDate(1, 0, 0)}
+	american := european
+	american.ExerciseStyle = options.AMERICAN
+
+	// the dividend lowers the value of calls
+	withoutDividends := pricing.TheoreticalValue(european, pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}, now)
+	assert.Less(t, pricing.TheoreticalValue(european, market, now), withoutDividends)
+
+	// the American call is exercised before the dividend
+	assert.Greater(t, pricing.TheoreticalValue(american, market, now), pricing.TheoreticalValue(european, market, now)+0.1)
+
+	// dividends after expiry are ignored
+	market.Dividends[0].Date = now.AddDate(2, 0, 0)
+	assert.InDelta(t, withoutDividends, pricing.TheoreticalValue(european, market, now), 1e-9)
+}
+
+func TestBinomialValueConvergesToBlackScholes(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.03, DividendYield: 0.01, Volatility: 0.3}
+
+	for _, strike := range []float64{80, 100, 120} {
+		call := options.OptionsContract{OptionsType: "Call", StrikePrice: strike, ExpirationDate: now.AddDate(0, 6, 0), ExerciseStyle: "american"}
+		european := call
+		european.ExerciseStyle = ""
+		// with a dividend yield, early exercise of the call is worth little
+		assert.InDelta(t, pricing.TheoreticalValue(european, market, now), pricing.TheoreticalValue(call, market, now), 0.05)
+	}
+}
+
+func TestBinomialGreeks(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+	call := options.OptionsContract{OptionsType: "Call", StrikePrice: 100, ExpirationDate: now.AddDate(1, 0, 0), ExerciseStyle: "american"}
+	european := call
+	european.ExerciseStyle = ""
+
+	// the American call is the European call. so are its greeks
+	assertGreeksInDelta(t, pricing.TheoreticalGreeks(european, market, now), pricing.TheoreticalGreeks(call, market, now), 0.02)
+
+	put := call
+	put.OptionsType = options.PUT
+	greeks := pricing.TheoreticalGreeks(put, market, now)
+	assert.Less(t, greeks.Delta, 0.0)
+	assert.Greater(t, greeks.Gamma, 0.0)
+	assert.Less(t, greeks.Theta, 0.0)
+	assert.Greater(t, greeks.Vega, 0.0)
+	assert.Less(t, greeks.Rho, 0.0)
+}
+
+func TestBinomialStepsOfMarket(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	put := options.OptionsContract{OptionsType: "Put", StrikePrice: 100, ExpirationDate: now.AddDate(1, 0, 0), ExerciseStyle: "American"}
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	coarse := market
+	coarse.BinomialSteps = 30
+	// fewer steps are less precise, and close
+	assert.NotEqual(t, pricing.TheoreticalValue(put, market, now), pricing.TheoreticalValue(put, coarse, now))
+	assert.InDelta(t, pricing.TheoreticalValue(put, market, now), pricing.TheoreticalValue(put, coarse, now), 0.05)
+}
@@ -0,0 +1,170 @@
+// theoretical values of options, before expiry
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// Market represents the market inputs for pricing options
+// rates and yields are annualized and continuously compounded. volatility is zero when it is not known
+type Market struct {
+	Spot          float64 `json:"spot"`
+	Rate          float64 `json:"rate"`
+	DividendYield float64 `json:"dividend_yield"`
+	Volatility    float64 `json:"volatility"`
+	// cash dividends on top of the dividend yield
+	Dividends []Dividend `json:"dividends,omitempty"`
+	// steps of the binomial trees of American options. BinomialSteps when zero. set by the analysis, not by clients
+	BinomialSteps int `json:"-"`
+}
+
+// Dividend represents a cash dividend per share, paid on the ex-dividend date
+type Dividend struct {
+	Date   time.Time `json:"date"`
+	Amount float64   `json:"amount"`
+}
+
+// bounds of the market inputs. values beyond them are not market data, and overflow the pricing models
+const (
+	MaxSpot       = 1e9
+	MaxVolatility = 10
+	// of the rate and of the dividend yield, either way
+	MaxRate = 1
+)
+
+// rates and dividend yields can be negative. all errors are returned, by field.
+// the comparisons reject NaN, which JSON cannot carry but protobuf can
+func (m Market) IsValid() error {
+	errs := appErrors.ValidationErrors{}
+	if !(m.Spot > 0) {
+		errs.Add("spot", appErrors.ErrInvalidSpotPrice)
+	} else if m.Spot > MaxSpot {
+		errs.Add("spot", fmt.Errorf("%w: expected at most %g", appErrors.ErrInvalidSpotPrice, float64(MaxSpot)))
+	}
+
+	if !(m.Volatility >= 0) {
+		errs.Add("volatility", appErrors.ErrInvalidVolatility)
+	} else if m.Volatility > MaxVolatility {
+		errs.Add("volatility", fmt.Errorf("%w: expected at most %g", appErrors.ErrInvalidVolatility, float64(MaxVolatility)))
+	}
+
+	if !(math.Abs(m.Rate) <= MaxRate) {
+		errs.Add("rate", fmt.Errorf("%w: expected -%g to %g", appErrors.ErrInvalidRate, float64(MaxRate), float64(MaxRate)))
+	}
+
+	if !(math.Abs(m.DividendYield) <= MaxRate) {
+		errs.Add("dividend_yield", fmt.Errorf("%w: expected -%g to %g", appErrors.ErrInvalidDividendYield, float64(MaxRate), float64(MaxRate)))
+	}
+
+	for i, d := range m.Dividends {
+		if !(d.Amount > 0 && d.Amount <= MaxSpot) || d.Date.IsZero() {
+			errs.Add(fmt.Sprintf("dividends[%d]", i), appErrors.ErrInvalidDividend)
+		}
+	}
+
+	return errs.Err()
+}
+
+// the cash dividends paid until the last expiration date of the options are worth less than the spot price,
+// so that the spot price without them stays positive. the market is valid otherwise
+func (m Market) ValidateDividends(contracts []options.OptionsContract, now time.Time) error {
+	until := time.Time{}
+	for _, c := range contracts {
+		if c.OptionsType.Value() != options.STOCK && c.ExpirationDate.After(until) {
+			until = c.ExpirationDate
+		}
+	}
+
+	if pv := m.presentValueOfDividends(now, until); !(pv < m.Spot) {
+		errs := appErrors.ValidationErrors{}
+		errs.Add("dividends", fmt.Errorf("%w: the dividends until expiry are worth %g, expected less than the spot price", appErrors.ErrInvalidDividend, pv))
+		return errs.Err()
+	}
+	return nil
+}
+
+// present value of the cash dividends paid after now and until the given time
+func (m Market) presentValueOfDividends(now, until time.Time) float64 {
+	pv := 0.0
+	for _, d := range m.Dividends {
+		if d.Date.After(now) && !d.Date.After(until) {
+			pv += d.Amount * math.Exp(-m.Rate*YearsToExpiry(now, d.Date))
+		}
+	}
+	return pv
+}
+
+// the spot price without the cash dividends paid until the given time. options on the underlying are priced with it.
+// it is the escrowed dividend model
+func (m Market) dividendAdjustedSpot(now, until time.Time) float64 {
+	return m.Spot - m.presentValueOfDividends(now, until)
+}
+
+// options expire at the given time. it is zero if they have already expired
+func YearsToExpiry(now, expirationDate time.Time) float64 {
+	return math.Max(0, expirationDate.Sub(now).Hours()/(365*24))
+}
+
+// theoretical value of a share of the contract, at the given time.
+// American options are priced with a binomial tree, and European options with Black-Scholes.
+// a stock leg is worth the spot price
+func TheoreticalValue(c options.OptionsContract, m Market, now time.Time) float64 {
+	if c.OptionsType.Value() == options.STOCK {
+		return m.Spot
+	}
+
+	if c.ExerciseStyle.Value() == options.AMERICAN {
+		return BinomialValue(c, m, now)
+	}
+
+	years := YearsToExpiry(now, c.ExpirationDate)
+	spot := m.dividendAdjustedSpot(now, c.ExpirationDate)
+	if c.OptionsType.Value() == options.CALL {
+		return BlackScholesCall(spot, c.StrikePrice, years, m.Rate, m.DividendYield, m.Volatility)
+	}
+	return BlackScholesPut(spot, c.StrikePrice, years, m.Rate, m.DividendYield, m.Volatility)
+}
+
+// profit or loss of all shares of the contract at the given time, if it is closed at its theoretical value
+func TheoreticalProfitOrLoss(c options.OptionsContract, m Market, now time.Time) float64 {
+	return c.LongShort.Sign() * (TheoreticalValue(c, m, now) - c.Premium()) * float64(c.Shares())
+}
+
+// Black-Scholes value of a European call. it is the intrinsic value at expiry
+func BlackScholesCall(spot, strike, years, rate, dividendYield, volatility float64) float64 {
+	if years <= 0 || volatility <= 0 {
+		return math.Max(0, forward(spot, years, rate, dividendYield)-strike) * math.Exp(-rate*years)
+	}
+
+	d1, d2 := d1d2(spot, strike, years, rate, dividendYield, volatility)
+	return spot*math.Exp(-dividendYield*years)*normCDF(d1) - strike*math.Exp(-rate*years)*normCDF(d2)
+}
+
+// Black-Scholes value of a European put. it is the intrinsic value at expiry
+func BlackScholesPut(spot, strike, years, rate, dividendYield, volatility float64) float64 {
+	if years <= 0 || volatility <= 0 {
+		return math.Max(0, strike-forward(spot, years, rate, dividendYield)) * math.Exp(-rate*years)
+	}
+
+	d1, d2 := d1d2(spot, strike, years, rate, dividendYield, volatility)
+	return strike*math.Exp(-rate*years)*normCDF(-d2) - spot*math.Exp(-dividendYield*years)*normCDF(-d1)
+}
+
+func forward(spot, years, rate, dividendYield float64) float64 {
+	return spot * math.Exp((rate-dividendYield)*years)
+}
+
+func d1d2(spot, strike, years, rate, dividendYield, volatility float64) (float64, float64) {
+	d1 := (math.Log(spot/strike) + (rate-dividendYield+volatility*volatility/2)*years) / (volatility * math.Sqrt(years))
+	return d1, d1 - volatility*math.Sqrt(years)
+}
+
+// cumulative distribution function of the standard normal distribution
+func normCDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
@@ -0,0 +1,96 @@
+package pricing_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackScholes(t *testing.T) {
+	assert.InDelta(t, 10.4506, pricing.BlackScholesCall(100, 100, 1, 0.05, 0, 0.2), 1e-4)
+	assert.InDelta(t, 5.5735, pricing.BlackScholesPut(100, 100, 1, 0.05, 0, 0.2), 1e-4)
+
+	// with a dividend yield
+	assert.InDelta(t, 8.6525, pricing.BlackScholesCall(100, 100, 1, 0.05, 0.03, 0.2), 1e-4)
+	assert.InDelta(t, 6.7309, pricing.BlackScholesPut(100, 100, 1, 0.05, 0.03, 0.2), 1e-4)
+}
+
+func TestBlackScholesPutCallParity(t *testing.T) {
+	for _, strike := range []float64{80, 100, 120} {
+		call := pricing.BlackScholesCall(100, strike, 0.5, 0.04, 0.01, 0.3)
+		put := pricing.BlackScholesPut(100, strike, 0.5, 0.04, 0.01, 0.3)
+		assert.InDelta(t, 100*math.Exp(-0.01*0.5)-strike*math.Exp(-0.04*0.5), call-put, 1e-9)
+	}
+}
+
+func TestBlackScholesAtExpiry(t *testing.T) {
+	assert.Equal(t, 20.0, pricing.BlackScholesCall(120, 100, 0, 0.05, 0, 0.2))
+	assert.Equal(t, 0.0, pricing.BlackScholesPut(120, 100, 0, 0.05, 0, 0.2))
+	assert.Equal(t, 15.0, pricing.BlackScholesPut(85, 100, 0, 0.05, 0, 0.2))
+}
+
+func TestTheoreticalValue(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	assert.InDelta(t, 10.4506, pricing.TheoreticalValue(options.OptionsContract{
+		OptionsType:    "Call",
+		StrikePrice:    100,
+		ExpirationDate: now.AddDate(1, 0, 0),
+	}, market, now), 1e-4)
+
+	assert.InDelta(t, 5.5735, pricing.TheoreticalValue(options.OptionsContract{
+		OptionsType:    "Put",
+		StrikePrice:    100,
+		ExpirationDate: now.AddDate(1, 0, 0),
+	}, market, now), 1e-4)
+
+	assert.Equal(t, 100.0, pricing.TheoreticalValue(options.OptionsContract{
+		OptionsType: "Stock",
+		EntryPrice:  90,
+	}, market, now))
+}
+
+func TestMarketIsValid(t *testing.T) {
+	assert.ErrorIs(t, pricing.Market{Volatility: 0.2}.IsValid(), errors.ErrInvalidSpotPrice)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Volatility: -0.2}.IsValid(), errors.ErrInvalidVolatility)
+	// volatility is not known
+	assert.NoError(t, pricing.Market{Spot: 100}.IsValid())
+	assert.NoError(t, pricing.Market{Spot: 100, Rate: -0.01, Volatility: 0.2}.IsValid())
+}
+
+func TestMarketValidateDividends(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := options.OptionsContract{OptionsType: "Call", StrikePrice: 100, ExpirationDate: now.AddDate(1, 0, 0)}
+	stock := options.OptionsContract{OptionsType: "Stock", EntryPrice: 100}
+	market := pricing.Market{Spot: 100, Volatility: 0.2, Dividends: []pricing.Dividend{{Date: now.AddDate(0, 6, 0), Amount: 60}, {Date: now.AddDate(0, 9, 0), Amount: 40}}}
+
+	// the spot price without the dividends is not positive
+	assert.ErrorIs(t, market.ValidateDividends([]options.OptionsContract{call, stock}, now), errors.ErrInvalidDividend)
+	// the second dividend is paid after expiry
+	call.ExpirationDate = now.AddDate(0, 8, 0)
+	assert.NoError(t, market.ValidateDividends([]options.OptionsContract{call, stock}, now))
+	assert.Greater(t, pricing.TheoreticalValue(call, market, now), 0.0)
+}
+
+func TestMarketIsValidBounds(t *testing.T) {
+	assert.ErrorIs(t, pricing.Market{Spot: 1e10, Volatility: 0.2}.IsValid(), errors.ErrInvalidSpotPrice)
+	assert.ErrorIs(t, pricing.Market{Spot: math.NaN(), Volatility: 0.2}.IsValid(), errors.ErrInvalidSpotPrice)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Volatility: 1e200}.IsValid(), errors.ErrInvalidVolatility)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Volatility: math.Inf(1)}.IsValid(), errors.ErrInvalidVolatility)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Rate: 5, Volatility: 0.2}.IsValid(), errors.ErrInvalidRate)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, DividendYield: -math.Inf(1), Volatility: 0.2}.IsValid(), errors.ErrInvalidDividendYield)
+	assert.NoError(t, pricing.Market{Spot: pricing.MaxSpot, Rate: -pricing.MaxRate, Volatility: pricing.MaxVolatility}.IsValid())
+}
+
+func TestMarketIsValidDividends(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Dividends: []pricing.Dividend{{Date: now, Amount: 0}}}.IsValid(), errors.ErrInvalidDividend)
+	assert.ErrorIs(t, pricing.Market{Spot: 100, Dividends: []pricing.Dividend{{Amount: 1}}}.IsValid(), errors.ErrInvalidDividend)
+	assert.NoError(t, pricing.Market{Spot: 100, Dividends: []pricing.Dividend{{Date: now, Amount: 1}}}.IsValid())
+}
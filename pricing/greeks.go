@@ -0,0 +1,118 @@
+package pricing
+
+import (
+	"math"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// Greeks represents the sensitivities of the value of an option
+// theta is per calendar day. vega and rho are per percentage point of volatility and rate
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Theta float64 `json:"theta"`
+	Vega  float64 `json:"vega"`
+	Rho   float64 `json:"rho"`
+}
+
+func (g Greeks) Add(o Greeks) Greeks {
+	return Greeks{
+		Delta: g.Delta + o.Delta,
+		Gamma: g.Gamma + o.Gamma,
+		Theta: g.Theta + o.Theta,
+		Vega:  g.Vega + o.Vega,
+		Rho:   g.Rho + o.Rho,
+	}
+}
+
+func (g Greeks) Scale(f float64) Greeks {
+	return Greeks{
+		Delta: g.Delta * f,
+		Gamma: g.Gamma * f,
+		Theta: g.Theta * f,
+		Vega:  g.Vega * f,
+		Rho:   g.Rho * f,
+	}
+}
+
+// greeks of a long share of the contract, at the given time.
+// American options have the greeks of their binomial values. a stock leg only has a delta
+func TheoreticalGreeks(c options.OptionsContract, m Market, now time.Time) Greeks {
+	if c.OptionsType.Value() == options.STOCK {
+		return Greeks{Delta: 1}
+	}
+
+	if c.ExerciseStyle.Value() == options.AMERICAN {
+		return binomialGreeks(c, m, now)
+	}
+
+	years := YearsToExpiry(now, c.ExpirationDate)
+	spot := m.dividendAdjustedSpot(now, c.ExpirationDate)
+	if c.OptionsType.Value() == options.CALL {
+		return BlackScholesCallGreeks(spot, c.StrikePrice, years, m.Rate, m.DividendYield, m.Volatility)
+	}
+	return BlackScholesPutGreeks(spot, c.StrikePrice, years, m.Rate, m.DividendYield, m.Volatility)
+}
+
+// Black-Scholes greeks of a European call. only the delta is left at expiry
+func BlackScholesCallGreeks(spot, strike, years, rate, dividendYield, volatility float64) Greeks {
+	if years <= 0 || volatility <= 0 {
+		if spot > strike {
+			return Greeks{Delta: 1}
+		}
+		return Greeks{}
+	}
+
+	d1, d2 := d1d2(spot, strike, years, rate, dividendYield, volatility)
+	dividendDiscount := math.Exp(-dividendYield * years)
+	discount := math.Exp(-rate * years)
+	return Greeks{
+		Delta: dividendDiscount * normCDF(d1),
+		Gamma: gamma(spot, years, dividendYield, volatility, d1),
+		Theta: (-spot*dividendDiscount*normPDF(d1)*volatility/(2*math.Sqrt(years)) -
+			rate*strike*discount*normCDF(d2) +
+			dividendYield*spot*dividendDiscount*normCDF(d1)) / 365,
+		Vega: vega(spot, years, dividendYield, d1),
+		Rho:  strike * years * discount * normCDF(d2) / 100,
+	}
+}
+
+// Black-Scholes greeks of a European put. only the delta is left at expiry
+func BlackScholesPutGreeks(spot, strike, years, rate, dividendYield, volatility float64) Greeks {
+	if years <= 0 || volatility <= 0 {
+		if spot < strike {
+			return Greeks{Delta: -1}
+		}
+		return Greeks{}
+	}
+
+	d1, d2 := d1d2(spot, strike, years, rate, dividendYield, volatility)
+	dividendDiscount := math.Exp(-dividendYield * years)
+	discount := math.Exp(-rate * years)
+	return Greeks{
+		Delta: -dividendDiscount * normCDF(-d1),
+		Gamma: gamma(spot, years, dividendYield, volatility, d1),
+		Theta: (-spot*dividendDiscount*normPDF(d1)*volatility/(2*math.Sqrt(years)) +
+			rate*strike*discount*normCDF(-d2) -
+			dividendYield*spot*dividendDiscount*normCDF(-d1)) / 365,
+		Vega: vega(spot, years, dividendYield, d1),
+		Rho:  -strike * years * discount * normCDF(-d2) / 100,
+	}
+}
+
+// gamma is the same for calls and puts
+func gamma(spot, years, dividendYield, volatility, d1 float64) float64 {
+	return math.Exp(-dividendYield*years) * normPDF(d1) / (spot * volatility * math.Sqrt(years))
+}
+
+// vega is the same for calls and puts
+func vega(spot, years, dividendYield, d1 float64) float64 {
+	return spot * math.Exp(-dividendYield*years) * normPDF(d1) * math.Sqrt(years) / 100
+}
+
+// probability density function of the standard normal distribution
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
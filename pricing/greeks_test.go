@@ -0,0 +1,90 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertGreeksInDelta(t *testing.T, expected, actual pricing.Greeks, delta float64) {
+	t.Helper()
+	assert.InDelta(t, expected.Delta, actual.Delta, delta, "delta")
+	assert.InDelta(t, expected.Gamma, actual.Gamma, delta, "gamma")
+	assert.InDelta(t, expected.Theta, actual.Theta, delta, "theta")
+	assert.InDelta(t, expected.Vega, actual.Vega, delta, "vega")
+	assert.InDelta(t, expected.Rho, actual.Rho, delta, "rho")
+}
+
+func TestBlackScholesGreeks(t *testing.T) {
+	assertGreeksInDelta(t, pricing.Greeks{
+		Delta: 0.6368,
+		Gamma: 0.0188,
+		Theta: -0.0176,
+		Vega:  0.3752,
+		Rho:   0.5323,
+	}, pricing.BlackScholesCallGreeks(100, 100, 1, 0.05, 0, 0.2), 1e-4)
+
+	assertGreeksInDelta(t, pricing.Greeks{
+		Delta: -0.3632,
+		Gamma: 0.0188,
+		Theta: -0.0045,
+		Vega:  0.3752,
+		Rho:   -0.4189,
+	}, pricing.BlackScholesPutGreeks(100, 100, 1, 0.05, 0, 0.2), 1e-4)
+}
+
+// greeks are the sensitivities of the theoretical value. compare them with finite differences
+func TestBlackScholesGreeksMatchFiniteDifferences(t *testing.T) {
+	for _, price := range []struct {
+		value  func(spot, strike, years, rate, dividendYield, volatility float64) float64
+		greeks func(spot, strike, years, rate, dividendYield, volatility float64) pricing.Greeks
+	}{
+		{pricing.BlackScholesCall, pricing.BlackScholesCallGreeks},
+		{pricing.BlackScholesPut, pricing.BlackScholesPutGreeks},
+	} {
+		spot, strike, years, rate, dividendYield, volatility := 105.0, 100.0, 0.5, 0.03, 0.01, 0.25
+		h := 1e-4
+		greeks := price.greeks(spot, strike, years, rate, dividendYield, volatility)
+
+		delta := (price.value(spot+h, strike, years, rate, dividendYield, volatility) - price.value(spot-h, strike, years, rate, dividendYield, volatility)) / (2 * h)
+		assert.InDelta(t, delta, greeks.Delta, 1e-6)
+
+		gamma := (price.value(spot+h, strike, years, rate, dividendYield, volatility) - 2*price.value(spot, strike, years, rate, dividendYield, volatility) + price.value(spot-h, strike, years, rate, dividendYield, volatility)) / (h * h)
+		assert.InDelta(t, gamma, greeks.Gamma, 1e-3)
+
+		theta := (price.value(spot, strike, years-h, rate, dividendYield, volatility) - price.value(spot, strike, years+h, rate, dividendYield, volatility)) / (2 * h) / 365
+		assert.InDelta(t, theta, greeks.Theta, 1e-6)
+
+		vega := (price.value(spot, strike, years, rate, dividendYield, volatility+h) - price.value(spot, strike, years, rate, dividendYield, volatility-h)) / (2 * h) / 100
+		assert.InDelta(t, vega, greeks.Vega, 1e-6)
+
+		rho := (price.value(spot, strike, years, rate+h, dividendYield, volatility) - price.value(spot, strike, years, rate-h, dividendYield, volatility)) / (2 * h) / 100
+		assert.InDelta(t, rho, greeks.Rho, 1e-6)
+	}
+}
+
+func TestTheoreticalGreeks(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+
+	assert.InDelta(t, 0.6368, pricing.TheoreticalGreeks(options.OptionsContract{
+		OptionsType:    "Call",
+		StrikePrice:    100,
+		ExpirationDate: now.AddDate(1, 0, 0),
+	}, market, now).Delta, 1e-4)
+
+	assert.Equal(t, pricing.Greeks{Delta: 1}, pricing.TheoreticalGreeks(options.OptionsContract{
+		OptionsType: "Stock",
+		EntryPrice:  90,
+	}, market, now))
+
+	// expired
+	assert.Equal(t, pricing.Greeks{Delta: -1}, pricing.TheoreticalGreeks(options.OptionsContract{
+		OptionsType:    "Put",
+		StrikePrice:    110,
+		ExpirationDate: now,
+	}, market, now))
+}
@@ -0,0 +1,81 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// bounds of the implied volatility solver. the value of an option is monotonic in volatility between them
+const (
+	MinImpliedVolatility = 1e-4
+	MaxImpliedVolatility = 5.0
+)
+
+const (
+	impliedVolatilityTolerance     = 1e-8
+	impliedVolatilityMaxIterations = 100
+)
+
+// volatility at which the Black-Scholes value of a share of the contract is the given price.
+// it is the European volatility of American options too
+func ImpliedVolatility(c options.OptionsContract, price float64, m Market, now time.Time) (float64, error) {
+	years := YearsToExpiry(now, c.ExpirationDate)
+	spot := m.dividendAdjustedSpot(now, c.ExpirationDate)
+	switch c.OptionsType.Value() {
+	case options.CALL:
+		return impliedVolatility(BlackScholesCall, price, spot, c.StrikePrice, years, m.Rate, m.DividendYield)
+	case options.PUT:
+		return impliedVolatility(BlackScholesPut, price, spot, c.StrikePrice, years, m.Rate, m.DividendYield)
+	}
+	return 0, fmt.Errorf("%w: %s has no volatility", appErrors.ErrNoImpliedVolatility, c.OptionsType)
+}
+
+// Newton's method, safeguarded by bisection. the value is increasing in volatility, so the root is always bracketed.
+// a Newton step that leaves the bracket, or does not shrink it fast enough, is replaced by bisection
+func impliedVolatility(value func(spot, strike, years, rate, dividendYield, volatility float64) float64, price, spot, strike, years, rate, dividendYield float64) (float64, error) {
+	if years <= 0 {
+		return 0, fmt.Errorf("%w: the contract has expired", appErrors.ErrNoImpliedVolatility)
+	}
+
+	lo, hi := MinImpliedVolatility, MaxImpliedVolatility
+	valueLo := value(spot, strike, years, rate, dividendYield, lo)
+	valueHi := value(spot, strike, years, rate, dividendYield, hi)
+	if price < valueLo-impliedVolatilityTolerance {
+		return 0, fmt.Errorf("%w: price %.2f is below the intrinsic value %.2f", appErrors.ErrNoImpliedVolatility, price, valueLo)
+	}
+	if price > valueHi+impliedVolatilityTolerance {
+		return 0, fmt.Errorf("%w: price %.2f is above the maximum value %.2f", appErrors.ErrNoImpliedVolatility, price, valueHi)
+	}
+
+	// Brenner-Subrahmanyam approximation for at the money options
+	volatility := math.Min(hi, math.Max(lo, math.Sqrt(2*math.Pi/years)*price/spot))
+	for i := 0; i < impliedVolatilityMaxIterations; i++ {
+		diff := value(spot, strike, years, rate, dividendYield, volatility) - price
+		if math.Abs(diff) < impliedVolatilityTolerance {
+			return volatility, nil
+		}
+
+		if diff > 0 {
+			hi = volatility
+		} else {
+			lo = volatility
+		}
+
+		d1, _ := d1d2(spot, strike, years, rate, dividendYield, volatility)
+		vega := vega(spot, years, dividendYield, d1) * 100
+		next := volatility - diff/vega
+		if vega <= 0 || next <= lo || next >= hi || math.Abs(next-volatility) > (hi-lo)/2 {
+			next = (lo + hi) / 2
+		}
+
+		if hi-lo < impliedVolatilityTolerance {
+			return next, nil
+		}
+		volatility = next
+	}
+	return volatility, nil
+}
@@ -0,0 +1,66 @@
+package pricing_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpliedVolatility(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05, DividendYield: 0.01}
+
+	// the implied volatility of the theoretical value is the volatility it is priced with
+	for _, optionsType := range []options.OptionsType{options.CALL, options.PUT} {
+		for _, strike := range []float64{50, 90, 100, 110, 200} {
+			for _, volatility := range []float64{0.05, 0.2, 0.8, 2} {
+				contract := options.OptionsContract{
+					OptionsType:    optionsType,
+					StrikePrice:    strike,
+					ExpirationDate: now.AddDate(0, 6, 0),
+				}
+				market.Volatility = volatility
+				price := pricing.TheoreticalValue(contract, market, now)
+				iv, err := pricing.ImpliedVolatility(contract, price, market, now)
+				assert.NoError(t, err)
+
+				// far from the money, the value hardly depends on volatility. any volatility in a range reprices it
+				ivMarket := market
+				ivMarket.Volatility = iv
+				assert.InDelta(t, price, pricing.TheoreticalValue(contract, ivMarket, now), 1e-7, "%s %v %v", optionsType, strike, volatility)
+				if pricing.TheoreticalGreeks(contract, market, now).Vega > 0.01 {
+					assert.InDelta(t, volatility, iv, 1e-6, "%s %v %v", optionsType, strike, volatility)
+				}
+			}
+		}
+	}
+}
+
+func TestImpliedVolatilityHasNoSolution(t *testing.T) {
+	now := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := pricing.Market{Spot: 100, Rate: 0.05}
+	call := options.OptionsContract{
+		OptionsType:    options.CALL,
+		StrikePrice:    80,
+		ExpirationDate: now.AddDate(0, 6, 0),
+	}
+
+	// below the intrinsic value
+	_, err := pricing.ImpliedVolatility(call, 15, market, now)
+	assert.ErrorIs(t, err, errors.ErrNoImpliedVolatility)
+
+	// a call is never worth more than the underlying
+	_, err = pricing.ImpliedVolatility(call, 101, market, now)
+	assert.ErrorIs(t, err, errors.ErrNoImpliedVolatility)
+
+	// expired
+	_, err = pricing.ImpliedVolatility(call, 20, market, call.ExpirationDate)
+	assert.ErrorIs(t, err, errors.ErrNoImpliedVolatility)
+
+	_, err = pricing.ImpliedVolatility(options.OptionsContract{OptionsType: options.STOCK, EntryPrice: 100}, 100, market, now)
+	assert.ErrorIs(t, err, errors.ErrNoImpliedVolatility)
+}
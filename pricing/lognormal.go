@@ -0,0 +1,89 @@
+package pricing
+
+import "math"
+
+// ExpectationIntervals is the number of intervals of the numerical integration of expectations. it is one more valuation of f
+const ExpectationIntervals = 2000
+
+// Lognormal represents the risk neutral distribution of the underlying price, after the given years
+type Lognormal struct {
+	Spot          float64
+	Years         float64
+	Rate          float64
+	DividendYield float64
+	Volatility    float64
+}
+
+func NewLognormal(m Market, years float64) Lognormal {
+	return Lognormal{
+		Spot:          m.Spot,
+		Years:         years,
+		Rate:          m.Rate,
+		DividendYield: m.DividendYield,
+		Volatility:    m.Volatility,
+	}
+}
+
+// drift and standard deviation of the log return
+func (l Lognormal) drift() float64 {
+	return (l.Rate - l.DividendYield - l.Volatility*l.Volatility/2) * l.Years
+}
+
+func (l Lognormal) stdDev() float64 {
+	return l.Volatility * math.Sqrt(l.Years)
+}
+
+// probability of the price being below the given price
+func (l Lognormal) CDF(price float64) float64 {
+	if price <= 0 {
+		return 0
+	}
+	if math.IsInf(price, 1) {
+		return 1
+	}
+	return normCDF((math.Log(price/l.Spot) - l.drift()) / l.stdDev())
+}
+
+// probability of the price being between the given prices
+func (l Lognormal) Probability(from, to float64) float64 {
+	return math.Max(0, l.CDF(to)-l.CDF(from))
+}
+
+// probability of the price touching the barrier at any time until the end, under geometric brownian motion
+func (l Lognormal) TouchProbability(barrier float64) float64 {
+	if barrier <= 0 {
+		return 0
+	}
+
+	b := math.Log(barrier / l.Spot)
+	if b == 0 {
+		return 1
+	}
+
+	nu := l.drift() / l.Years
+	sigma := l.stdDev()
+	reflection := math.Exp(2 * nu * b / (l.Volatility * l.Volatility))
+	if b > 0 {
+		return normCDF((-b+nu*l.Years)/sigma) + reflection*normCDF((-b-nu*l.Years)/sigma)
+	}
+	return normCDF((b-nu*l.Years)/sigma) + reflection*normCDF((b+nu*l.Years)/sigma)
+}
+
+// expected value of f of the price. Simpson's rule over 8 standard deviations on both sides
+func (l Lognormal) Expectation(f func(price float64) float64) float64 {
+	const width = 8.0
+	h := 2 * width / ExpectationIntervals
+	integrand := func(z float64) float64 {
+		return f(l.Spot*math.Exp(l.drift()+l.stdDev()*z)) * normPDF(z)
+	}
+
+	sum := integrand(-width) + integrand(width)
+	for i := 1; i < ExpectationIntervals; i++ {
+		weight := 2.0
+		if i%2 == 1 {
+			weight = 4
+		}
+		sum += weight * integrand(-width+float64(i)*h)
+	}
+	return sum * h / 3
+}
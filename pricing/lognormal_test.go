@@ -0,0 +1,58 @@
+package pricing_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLognormal(t *testing.T) {
+	l := pricing.NewLognormal(pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}, 1)
+
+	assert.Equal(t, 0.0, l.CDF(0))
+	assert.Equal(t, 1.0, l.CDF(math.Inf(1)))
+	// the median is below the forward, at spot * e^((r - σ²/2)T)
+	assert.InDelta(t, 0.5, l.CDF(100*math.Exp(0.05-0.02)), 1e-12)
+	assert.InDelta(t, 1, l.Probability(0, 50)+l.Probability(50, 150)+l.Probability(150, math.Inf(1)), 1e-12)
+
+	// risk neutral expectations are forwards and undiscounted option values
+	assert.InDelta(t, 100*math.Exp(0.05), l.Expectation(func(x float64) float64 { return x }), 1e-6)
+	// the kink of the payoff costs the integration some precision
+	assert.InDelta(t, pricing.BlackScholesCall(100, 100, 1, 0.05, 0, 0.2)*math.Exp(0.05), l.Expectation(func(x float64) float64 { return math.Max(0, x-100) }), 1e-4)
+}
+
+func TestLognormalTouchProbability(t *testing.T) {
+	l := pricing.NewLognormal(pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}, 1)
+
+	assert.Equal(t, 1.0, l.TouchProbability(100))
+	// touching is at least as likely as finishing beyond the barrier
+	assert.Greater(t, l.TouchProbability(120), 1-l.CDF(120))
+	assert.Greater(t, l.TouchProbability(80), l.CDF(80))
+	assert.Greater(t, l.TouchProbability(110), l.TouchProbability(120))
+
+	// compare with simulated paths
+	rng := rand.New(rand.NewSource(1))
+	steps, paths := 1000, 4000
+	dt := 1.0 / float64(steps)
+	touchedUp, touchedDown := 0, 0
+	for i := 0; i < paths; i++ {
+		x, up, down := 100.0, false, false
+		for j := 0; j < steps; j++ {
+			x *= math.Exp((0.05-0.02)*dt + 0.2*math.Sqrt(dt)*rng.NormFloat64())
+			up = up || x >= 120
+			down = down || x <= 80
+		}
+		if up {
+			touchedUp++
+		}
+		if down {
+			touchedDown++
+		}
+	}
+	// discrete monitoring misses some touches
+	assert.InDelta(t, l.TouchProbability(120), float64(touchedUp)/float64(paths), 0.04)
+	assert.InDelta(t, l.TouchProbability(80), float64(touchedDown)/float64(paths), 0.04)
+}
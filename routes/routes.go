@@ -1,16 +1,83 @@
 package routes
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aries-financial-inc/options-service/api"
 	"github.com/aries-financial-inc/options-service/controllers"
+	appErrors "github.com/aries-financial-inc/options-service/errors"
 	"github.com/gin-gonic/gin"
 )
 
+// MaxRequestBytes is the maximum size of the body of a request. it is configured at startup
+var MaxRequestBytes int64 = 1 << 20
+
 func SetupRouter() *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.Use(gin.Logger(), gin.CustomRecovery(recovery), limitRequestBytes)
+
+	router.NoRoute(func(c *gin.Context) {
+		controllers.WriteError(c.Writer, appErrors.NotFound(fmt.Errorf("no route for %s %s", c.Request.Method, c.Request.URL.Path)))
+	})
+
+	router.NoMethod(func(c *gin.Context) {
+		controllers.WriteError(c.Writer, appErrors.MethodNotAllowed(fmt.Errorf("method %s is not allowed on %s", c.Request.Method, c.Request.URL.Path)))
+	})
+
+	// the unversioned routes are kept for the clients of the first API. new clients use /v1
+	v1 := router.Group("/v1")
+	registerRoutes(router)
+	registerRoutes(v1)
 
+	v1.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", api.OpenAPI)
+	})
+
+	return router
+}
+
+// the endpoints of the API, described by its OpenAPI document
+func registerRoutes(router gin.IRoutes) {
 	router.POST("/analyze", func(c *gin.Context) {
 		controllers.AnalysisHandler(c.Writer, c.Request)
 	})
 
-	return router
+	router.POST("/analyze/batch", func(c *gin.Context) {
+		controllers.BatchHandler(c.Writer, c.Request)
+	})
+
+	router.POST("/price", func(c *gin.Context) {
+		controllers.PricingHandler(c.Writer, c.Request)
+	})
+
+	router.POST("/simulate", func(c *gin.Context) {
+		controllers.SimulationHandler(c.Writer, c.Request)
+	})
+
+	router.GET("/strategies", func(c *gin.Context) {
+		controllers.StrategiesHandler(c.Writer, c.Request)
+	})
+
+	router.POST("/strategies/:name", func(c *gin.Context) {
+		controllers.StrategyHandler(c.Writer, c.Request, c.Param("name"))
+	})
+}
+
+// reading more than MaxRequestBytes of the body fails, and the handlers answer that the request is too large
+func limitRequestBytes(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxRequestBytes)
+	c.Next()
+}
+
+// a panic of a handler is an internal error
+func recovery(c *gin.Context, recovered any) {
+	err, ok := recovered.(error)
+	if !ok {
+		err = errors.New(fmt.Sprint(recovered))
+	}
+	controllers.WriteError(c.Writer, appErrors.Internal(fmt.Errorf("panic: %w", err)))
+	c.Abort()
 }
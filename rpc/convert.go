@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/aries-financial-inc/options-service/analysispb"
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/costs"
+	"github.com/aries-financial-inc/options-service/margin"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// a missing timestamp is the zero time, like a missing date of the JSON API
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func fromTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toAnalysisRequest(req *analysispb.AnalyzeRequest) controllers.AnalysisRequest {
+	r := controllers.AnalysisRequest{
+		ValuationDate: toTime(req.GetValuationDate()),
+		Spot:          req.GetSpot(),
+	}
+
+	for _, c := range req.GetContracts() {
+		r.Contracts = append(r.Contracts, options.OptionsContract{
+			OptionsType:    options.OptionsType(c.GetType()),
+			StrikePrice:    c.GetStrikePrice(),
+			Bid:            c.GetBid(),
+			Ask:            c.GetAsk(),
+			ExpirationDate: toTime(c.GetExpirationDate()),
+			LongShort:      options.LongShort(c.GetLongShort()),
+			Quantity:       int(c.GetQuantity()),
+			Multiplier:     int(c.GetMultiplier()),
+			EntryPrice:     c.GetEntryPrice(),
+			ExerciseStyle:  options.ExerciseStyle(c.GetExerciseStyle()),
+			FillPrice:      c.GetFillPrice(),
+		})
+	}
+
+	if m := req.GetMarket(); m != nil {
+		r.Market = &pricing.Market{
+			Spot:          m.GetSpot(),
+			Rate:          m.GetRate(),
+			DividendYield: m.GetDividendYield(),
+			Volatility:    m.GetVolatility(),
+		}
+		for _, d := range m.GetDividends() {
+			r.Market.Dividends = append(r.Market.Dividends, pricing.Dividend{Date: toTime(d.GetDate()), Amount: d.GetAmount()})
+		}
+	}
+
+	for _, date := range req.GetEvaluationDates() {
+		r.EvaluationDates = append(r.EvaluationDates, toTime(date))
+	}
+
+	if c := req.GetCosts(); c != nil {
+		r.Costs = &costs.Model{
+			CommissionPerContract:  c.GetCommissionPerContract(),
+			ExchangeFeePerContract: c.GetExchangeFeePerContract(),
+			FeePerOrder:            c.GetFeePerOrder(),
+			Fill:                   costs.FillAssumption(c.GetFill()),
+		}
+	}
+
+	if rg := req.GetRange(); rg != nil {
+		r.Range = &controllers.Range{
+			Mode:    controllers.RangeMode(rg.GetMode()),
+			Percent: rg.GetPercent(),
+			Min:     rg.GetMin(),
+			Max:     rg.GetMax(),
+			StdDevs: rg.GetStdDevs(),
+			Points:  int(rg.GetPoints()),
+		}
+	}
+	return r
+}
+
+func fromAnalysisResponse(resp controllers.AnalysisResponse) *analysispb.AnalyzeResponse {
+	r := &analysispb.AnalyzeResponse{
+		XyValues:        fromXYValues(resp.XYValues),
+		MaxProfit:       fromExtremum(resp.MaxProfit),
+		MaxLoss:         fromExtremum(resp.MaxLoss),
+		BreakEvenPoints: resp.BreakEvenPoints,
+		Strategy:        &analysispb.Strategy{Name: resp.Strategy.Name, Risk: string(resp.Strategy.Risk)},
+		Costs:           fromCosts(resp.Costs),
+		Margin:          fromMargin(resp.Margin),
+		Metrics: &analysispb.Metrics{
+			Entry:              string(resp.Metrics.Entry),
+			NetPremium:         resp.Metrics.NetPremium,
+			RiskReward:         resp.Metrics.RiskReward,
+			ReturnOnMaxLoss:    resp.Metrics.ReturnOnMaxLoss,
+			ReturnOnMargin:     resp.Metrics.ReturnOnMargin,
+			BreakEvenDistances: resp.Metrics.BreakEvenDistances,
+		},
+	}
+
+	if resp.Greeks != nil {
+		r.Greeks = &analysispb.GreeksResult{Position: fromGreeks(resp.Greeks.Position)}
+		for _, g := range resp.Greeks.Contracts {
+			r.Greeks.Contracts = append(r.Greeks.Contracts, fromGreeks(g))
+		}
+	}
+
+	for _, iv := range resp.ImpliedVolatilities {
+		r.ImpliedVolatilities = append(r.ImpliedVolatilities, &analysispb.ImpliedVolatility{Mid: iv.Mid, Bid: iv.Bid, Ask: iv.Ask, Error: iv.Error})
+	}
+
+	for _, c := range resp.Curves {
+		r.Curves = append(r.Curves, &analysispb.Curve{Name: c.Name, Date: fromTime(c.Date), XyValues: fromXYValues(c.XYValues)})
+	}
+
+	if p := resp.Probabilities; p != nil {
+		r.Probabilities = &analysispb.Probabilities{
+			Volatility:                  p.Volatility,
+			ProbabilityOfProfit:         p.ProbabilityOfProfit,
+			ProbabilityOfMaxProfit:      p.ProbabilityOfMaxProfit,
+			BreakEvenTouchProbabilities: p.BreakEvenTouchProbabilities,
+			ExpectedProfitOrLoss:        p.ExpectedProfitOrLoss,
+		}
+	}
+	return r
+}
+
+func fromXYValues(xyValues []controllers.XYValue) []*analysispb.XYValue {
+	values := []*analysispb.XYValue{}
+	for _, v := range xyValues {
+		values = append(values, &analysispb.XYValue{X: v.X, Y: v.Y})
+	}
+	return values
+}
+
+func fromExtremum(e controllers.Extremum) *analysispb.Extremum {
+	return &analysispb.Extremum{Value: e.Value, Price: e.Price, Unlimited: e.Unlimited}
+}
+
+func fromGreeks(g pricing.Greeks) *analysispb.Greeks {
+	return &analysispb.Greeks{Delta: g.Delta, Gamma: g.Gamma, Theta: g.Theta, Vega: g.Vega, Rho: g.Rho}
+}
+
+func fromCosts(c *costs.Costs) *analysispb.Costs {
+	if c == nil {
+		return nil
+	}
+	return &analysispb.Costs{Commissions: c.Commissions, ExchangeFees: c.ExchangeFees, OrderFees: c.OrderFees, Total: c.Total, Slippage: c.Slippage}
+}
+
+func fromMargin(m *margin.Margin) *analysispb.Margin {
+	if m == nil {
+		return nil
+	}
+
+	r := &analysispb.Margin{Requirement: m.Requirement}
+	for _, req := range m.Requirements {
+		legs := []int32{}
+		for _, leg := range req.Legs {
+			legs = append(legs, int32(leg))
+		}
+		r.Requirements = append(r.Requirements, &analysispb.MarginRequirement{Rule: req.Rule, Legs: legs, Amount: req.Amount})
+	}
+	return r
+}
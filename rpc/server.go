@@ -0,0 +1,90 @@
+// gRPC interface of the service, next to the HTTP API. it shares the analysis of the controllers
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aries-financial-inc/options-service/analysispb"
+	"github.com/aries-financial-inc/options-service/controllers"
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// the domain of the error infos of the service
+const errorDomain = "options-service"
+
+// Server implements the analysis service
+type Server struct {
+	analysispb.UnimplementedAnalysisServiceServer
+}
+
+// NewServer returns a gRPC server with the analysis service registered. panics are recovered, before the interceptors of the options
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(RecoveryInterceptor)}, opts...)...)
+	analysispb.RegisterAnalysisServiceServer(server, &Server{})
+	return server
+}
+
+// RecoveryInterceptor turns a panic of a call into an internal error, rather than a crash of the server
+func RecoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			resp, err = nil, toStatus(appErrors.Internal(fmt.Errorf("panic: %v", recovered)))
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (s *Server) Analyze(ctx context.Context, req *analysispb.AnalyzeRequest) (*analysispb.AnalyzeResponse, error) {
+	resp, err := controllers.AnalyzeRequest(toAnalysisRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fromAnalysisResponse(resp), nil
+}
+
+// the gRPC codes of the HTTP statuses of application errors
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusRequestEntityTooLarge: codes.ResourceExhausted,
+	http.StatusMethodNotAllowed:      codes.Unimplemented,
+}
+
+// the status of the application error. the code of the application error is the reason of the error info,
+// and the validation errors are field violations, described by their own codes. the causes of internal errors are not exposed
+func toStatus(err error) error {
+	appErr := appErrors.From(err)
+	code, ok := statusCodes[appErr.Status]
+	if !ok {
+		log.Printf("internal error: %v", appErr.Err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}}
+	validationErrors := appErrors.ValidationErrors{}
+	if errors.As(appErr, &validationErrors) {
+		badRequest := &errdetails.BadRequest{}
+		for _, e := range validationErrors {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       e.Path(),
+				Description: appErrors.Code(e.Err) + ": " + e.Err.Error(),
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st, err := status.New(code, appErr.Error()).WithDetails(details...)
+	if err != nil {
+		return status.Error(code, appErr.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,168 @@
+package rpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/analysispb"
+	"github.com/aries-financial-inc/options-service/controllers"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/aries-financial-inc/options-service/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// a client of the analysis service, served in process
+func newClient(t *testing.T) analysispb.AnalysisServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := rpc.NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return analysispb.NewAnalysisServiceClient(conn)
+}
+
+func TestAnalyze(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	testdata, err := os.ReadFile("../testdata/testdata.json")
+	require.NoError(t, err)
+	contracts := []options.OptionsContract{}
+	require.NoError(t, json.Unmarshal(testdata, &contracts))
+
+	t.Run("same analysis as the HTTP API", func(t *testing.T) {
+		valuationDate := time.Date(2099, 6, 17, 0, 0, 0, 0, time.UTC)
+		req := &analysispb.AnalyzeRequest{
+			Market:        &analysispb.Market{Spot: 105, Rate: 0.05, Volatility: 0.25},
+			ValuationDate: timestamppb.New(valuationDate),
+		}
+		for _, c := range contracts {
+			req.Contracts = append(req.Contracts, &analysispb.OptionsContract{
+				Type:           string(c.OptionsType),
+				StrikePrice:    c.StrikePrice,
+				Bid:            c.Bid,
+				Ask:            c.Ask,
+				ExpirationDate: timestamppb.New(c.ExpirationDate),
+				LongShort:      string(c.LongShort),
+			})
+		}
+
+		resp, err := client.Analyze(ctx, req)
+		require.NoError(t, err)
+
+		expected, err := controllers.AnalyzeRequest(controllers.AnalysisRequest{
+			Contracts:     contracts,
+			Market:        &pricing.Market{Spot: 105, Rate: 0.05, Volatility: 0.25},
+			ValuationDate: valuationDate,
+		})
+		require.NoError(t, err)
+
+		assert.Len(t, resp.XyValues, len(expected.XYValues))
+		assert.Equal(t, expected.BreakEvenPoints, resp.BreakEvenPoints)
+		assert.Equal(t, expected.MaxProfit.Value, resp.MaxProfit.Value)
+		assert.Equal(t, expected.MaxLoss.Value, resp.MaxLoss.Value)
+		assert.Equal(t, expected.Strategy.Name, resp.Strategy.Name)
+		assert.Equal(t, expected.Greeks.Position.Delta, resp.Greeks.Position.Delta)
+		assert.Len(t, resp.ImpliedVolatilities, len(contracts))
+		assert.Len(t, resp.Curves, len(expected.Curves))
+		assert.Equal(t, expected.Probabilities.ProbabilityOfProfit, resp.Probabilities.ProbabilityOfProfit)
+	})
+
+	t.Run("unlimited profit", func(t *testing.T) {
+		resp, err := client.Analyze(ctx, &analysispb.AnalyzeRequest{
+			Contracts: []*analysispb.OptionsContract{
+				{Type: "call", StrikePrice: 100, Bid: 10.05, Ask: 12.04, LongShort: "long", ExpirationDate: timestamppb.New(time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC))},
+			},
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.MaxProfit.Unlimited)
+		assert.Equal(t, &analysispb.Extremum{Value: -1204, Price: 0}, resp.MaxLoss)
+		assert.Nil(t, resp.Metrics.RiskReward)
+		assert.Nil(t, resp.Greeks)
+	})
+
+	t.Run("invalid argument with all errors", func(t *testing.T) {
+		_, err := client.Analyze(ctx, &analysispb.AnalyzeRequest{
+			Contracts: []*analysispb.OptionsContract{
+				{Type: "call", StrikePrice: 100, Bid: 14, Ask: 12, LongShort: "sideways", ExpirationDate: timestamppb.New(time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC))},
+			},
+		})
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		require.Len(t, st.Details(), 2)
+		assert.Equal(t, "invalid_request", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+		violations := st.Details()[1].(*errdetails.BadRequest).FieldViolations
+		require.Len(t, violations, 2)
+		assert.Equal(t, "contracts[0].ask", violations[0].Field)
+		assert.Equal(t, "contracts[0].long_short", violations[1].Field)
+		assert.Equal(t, "invalid_long_short: invalid longShort", violations[1].Description)
+	})
+}
+
+func TestAnalyzeNaN(t *testing.T) {
+	client := newClient(t)
+	expiry := timestamppb.New(time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC))
+	nan := math.NaN()
+
+	// the comparisons of the validation reject NaN
+	_, err := client.Analyze(context.Background(), &analysispb.AnalyzeRequest{
+		Contracts: []*analysispb.OptionsContract{
+			{Type: "call", StrikePrice: nan, Bid: nan, Ask: nan, FillPrice: nan, LongShort: "long", ExpirationDate: expiry},
+			{Type: "stock", EntryPrice: nan, LongShort: "long"},
+		},
+		Spot:  nan,
+		Costs: &analysispb.CostModel{CommissionPerContract: nan, ExchangeFeePerContract: nan, FeePerOrder: nan},
+	})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	fields := []string{}
+	for _, violation := range st.Details()[1].(*errdetails.BadRequest).FieldViolations {
+		fields = append(fields, violation.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"contracts[0].strike_price", "contracts[0].bid", "contracts[0].ask", "contracts[0].fill_price",
+		"contracts[1].entry_price",
+		"costs.commission_per_contract", "costs.exchange_fee_per_contract", "costs.fee_per_order",
+		"spot",
+	}, fields)
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/options.v1.AnalysisService/Analyze"}
+	_, err := rpc.RecoveryInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	// the cause is not exposed
+	assert.Equal(t, "internal error", status.Convert(err).Message())
+
+	resp, err := rpc.RecoveryInterceptor(context.Background(), "request", info, func(ctx context.Context, req any) (any, error) {
+		return req, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "request", resp)
+}
@@ -0,0 +1,274 @@
+// Monte Carlo simulation of the profit or loss of options along paths of the underlying price
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+)
+
+// bounds that protect the server. every step of every path values all the contracts
+const (
+	MaxPaths       = 100000
+	MaxSteps       = 1000
+	MaxEvaluations = 2000000
+	MaxBins        = 1000
+)
+
+// bounds of the jumps. larger jumps are not market moves, and overflow the drift. more jumps are slow to draw
+const (
+	MaxJumpIntensity = 100
+	// of the mean and of the standard deviation of the log of a jump
+	MaxJumpSize = 1
+)
+
+const (
+	DefaultPaths      = 10000
+	DefaultSteps      = 50
+	DefaultBins       = 50
+	DefaultConfidence = 0.95
+)
+
+// an American option is valued with a binomial tree. every node of the tree costs about as much as a valuation of a European option,
+// and a tree of n steps has n(n+1)/2 nodes besides its leaves
+func evaluationsPerStep(contracts []options.OptionsContract) float64 {
+	evaluations := 0.0
+	for _, c := range contracts {
+		if c.OptionsType.Value() != options.STOCK && c.ExerciseStyle.Value() == options.AMERICAN {
+			steps := float64(pricing.BinomialSteps)
+			evaluations += steps * (steps + 1) / 2
+			continue
+		}
+		evaluations++
+	}
+	return evaluations
+}
+
+// percentiles of the profit or loss in the result
+var percentiles = []float64{1, 5, 10, 25, 50, 75, 90, 95, 99}
+
+// Config represents the parameters of a simulation. zero values are replaced by the defaults
+type Config struct {
+	Paths int `json:"paths"`
+	Steps int `json:"steps"`
+	// the same seed gives the same result
+	Seed int64 `json:"seed"`
+	// geometric brownian motion without jumps
+	Jumps *Jumps `json:"jumps,omitempty"`
+	Bins  int    `json:"bins"`
+	// confidence level of the value at risk
+	Confidence float64 `json:"confidence"`
+	// the end of the paths. defaults to the nearest expiration date
+	Horizon time.Time `json:"horizon"`
+}
+
+// Jumps represents the lognormal jumps of Merton's jump diffusion
+type Jumps struct {
+	// expected number of jumps per year
+	Intensity float64 `json:"intensity"`
+	// mean and standard deviation of the log of a jump
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// Result represents the distribution of the profit or loss at the horizon
+type Result struct {
+	Paths       int          `json:"paths"`
+	Mean        float64      `json:"mean"`
+	Histogram   []Bin        `json:"histogram"`
+	Percentiles []Percentile `json:"percentiles"`
+	// losses are positive
+	ValueAtRisk            float64 `json:"value_at_risk"`
+	ConditionalValueAtRisk float64 `json:"conditional_value_at_risk"`
+	// mean of the lowest profit or loss along each path, when the contracts are valued at every step
+	MeanWorstProfitOrLoss float64 `json:"mean_worst_profit_or_loss"`
+}
+
+type Bin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type Percentile struct {
+	Percentile float64 `json:"percentile"`
+	Value      float64 `json:"value"`
+}
+
+// fill in the defaults and check the bounds
+func (c Config) withDefaults(contracts []options.OptionsContract, now time.Time) (Config, error) {
+	if c.Paths == 0 {
+		c.Paths = DefaultPaths
+	}
+	if c.Steps == 0 {
+		c.Steps = DefaultSteps
+	}
+	if c.Bins == 0 {
+		c.Bins = DefaultBins
+	}
+	if c.Confidence == 0 {
+		c.Confidence = DefaultConfidence
+	}
+	if c.Horizon.IsZero() {
+		c.Horizon, _ = options.NearestExpirationDate(contracts)
+	}
+
+	if c.Paths < 0 || c.Paths > MaxPaths {
+		return c, fmt.Errorf("%w: got %d, expected 1 to %d", appErrors.ErrInvalidSimulationPaths, c.Paths, MaxPaths)
+	}
+	if c.Steps < 0 || c.Steps > MaxSteps {
+		return c, fmt.Errorf("%w: got %d, expected 1 to %d", appErrors.ErrInvalidSimulationSteps, c.Steps, MaxSteps)
+	}
+	if float64(c.Paths*c.Steps)*evaluationsPerStep(contracts) > MaxEvaluations {
+		return c, fmt.Errorf("%w: %d paths of %d steps value the contracts more than %d times", appErrors.ErrInvalidSimulationPaths, c.Paths, c.Steps, MaxEvaluations)
+	}
+	if c.Bins < 0 || c.Bins > MaxBins {
+		return c, fmt.Errorf("%w: got %d, expected 1 to %d", appErrors.ErrInvalidSimulationBins, c.Bins, MaxBins)
+	}
+	if c.Confidence <= 0 || c.Confidence >= 1 {
+		return c, appErrors.ErrInvalidConfidence
+	}
+	if !c.Horizon.After(now) {
+		return c, appErrors.ErrInvalidSimulationHorizon
+	}
+	// the comparisons reject NaN, which JSON cannot carry but protobuf can
+	if c.Jumps != nil && !(c.Jumps.Intensity >= 0 && c.Jumps.Intensity <= MaxJumpIntensity &&
+		math.Abs(c.Jumps.Mean) <= MaxJumpSize && c.Jumps.StdDev >= 0 && c.Jumps.StdDev <= MaxJumpSize) {
+		return c, fmt.Errorf("%w: expected an intensity of 0 to %d, a mean of -%d to %d and a std_dev of 0 to %d",
+			appErrors.ErrInvalidJumps, MaxJumpIntensity, MaxJumpSize, MaxJumpSize, MaxJumpSize)
+	}
+	return c, nil
+}
+
+// Simulate returns the distribution of the profit or loss of the contracts at the horizon.
+// the underlying follows a geometric brownian motion, with jumps if they are given, under the risk neutral measure
+func Simulate(contracts []options.OptionsContract, market pricing.Market, now time.Time, config Config) (Result, error) {
+	config, err := config.withDefaults(contracts, now)
+	if err != nil {
+		return Result{}, err
+	}
+	if market.Volatility <= 0 {
+		return Result{}, appErrors.ErrInvalidVolatility
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	years := pricing.YearsToExpiry(now, config.Horizon)
+	dt := years / float64(config.Steps)
+	stepDuration := config.Horizon.Sub(now) / time.Duration(config.Steps)
+
+	drift := (market.Rate - market.DividendYield - market.Volatility*market.Volatility/2) * dt
+	diffusion := market.Volatility * math.Sqrt(dt)
+	if config.Jumps != nil {
+		// the expected jump is compensated, so that the underlying still grows at the risk neutral rate
+		drift -= config.Jumps.Intensity * (math.Exp(config.Jumps.Mean+config.Jumps.StdDev*config.Jumps.StdDev/2) - 1) * dt
+	}
+
+	profitsOrLosses := make([]float64, config.Paths)
+	worstSum := 0.0
+	for i := range profitsOrLosses {
+		m := market
+		worst := math.Inf(1)
+		for step := 1; step <= config.Steps; step++ {
+			logReturn := drift + diffusion*rng.NormFloat64()
+			if config.Jumps != nil {
+				jumps := poisson(rng, config.Jumps.Intensity*dt)
+				logReturn += float64(jumps)*config.Jumps.Mean + math.Sqrt(float64(jumps))*config.Jumps.StdDev*rng.NormFloat64()
+			}
+			m.Spot *= math.Exp(logReturn)
+
+			at := now.Add(time.Duration(step) * stepDuration)
+			if step == config.Steps {
+				at = config.Horizon
+			}
+			profitsOrLosses[i] = profitOrLoss(contracts, m, at)
+			worst = math.Min(worst, profitsOrLosses[i])
+		}
+		worstSum += worst
+	}
+
+	result := summarize(profitsOrLosses, config)
+	result.MeanWorstProfitOrLoss = roundToCents(worstSum / float64(config.Paths))
+	return result, nil
+}
+
+func summarize(profitsOrLosses []float64, config Config) Result {
+	sort.Float64s(profitsOrLosses)
+
+	result := Result{Paths: len(profitsOrLosses)}
+	sum := 0.0
+	for _, pl := range profitsOrLosses {
+		sum += pl
+	}
+	result.Mean = roundToCents(sum / float64(len(profitsOrLosses)))
+
+	for _, p := range percentiles {
+		result.Percentiles = append(result.Percentiles, Percentile{p, roundToCents(percentile(profitsOrLosses, p/100))})
+	}
+
+	// the expected loss in the worst (1 - confidence) of the paths
+	tail := int(math.Ceil((1 - config.Confidence) * float64(len(profitsOrLosses))))
+	tailSum := 0.0
+	for _, pl := range profitsOrLosses[:tail] {
+		tailSum += pl
+	}
+	result.ValueAtRisk = roundToCents(-percentile(profitsOrLosses, 1-config.Confidence))
+	result.ConditionalValueAtRisk = roundToCents(-tailSum / float64(tail))
+
+	result.Histogram = histogram(profitsOrLosses, config.Bins)
+	return result
+}
+
+// bins of equal width between the lowest and the highest profit or loss
+func histogram(sorted []float64, bins int) []Bin {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []Bin{{From: roundToCents(lo), To: roundToCents(hi), Count: len(sorted)}}
+	}
+
+	width := (hi - lo) / float64(bins)
+	histogram := make([]Bin, bins)
+	for i := range histogram {
+		histogram[i] = Bin{From: roundToCents(lo + float64(i)*width), To: roundToCents(lo + float64(i+1)*width)}
+	}
+	for _, pl := range sorted {
+		i := min(int((pl-lo)/width), bins-1)
+		histogram[i].Count++
+	}
+	return histogram
+}
+
+// linear interpolation between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// number of events of a Poisson distribution with the given mean. Knuth's algorithm, for the small means of a step
+func poisson(rng *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	n := 0
+	for p := rng.Float64(); p > limit; p *= rng.Float64() {
+		n++
+	}
+	return n
+}
+
+func profitOrLoss(contracts []options.OptionsContract, m pricing.Market, now time.Time) float64 {
+	profitOrLoss := 0.0
+	for _, c := range contracts {
+		profitOrLoss += pricing.TheoreticalProfitOrLoss(c, m, now)
+	}
+	return profitOrLoss
+}
+
+func roundToCents(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,139 @@
+package simulation_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/pricing"
+	"github.com/aries-financial-inc/options-service/simulation"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	now    = time.Date(2098, 12, 17, 0, 0, 0, 0, time.UTC)
+	market = pricing.Market{Spot: 100, Rate: 0.05, Volatility: 0.2}
+	call   = options.OptionsContract{
+		StrikePrice:    100,
+		OptionsType:    "Call",
+		Bid:            10.05,
+		Ask:            10.45,
+		LongShort:      "long",
+		ExpirationDate: now.AddDate(1, 0, 0),
+	}
+)
+
+func TestSimulateIsDeterministic(t *testing.T) {
+	config := simulation.Config{Paths: 1000, Steps: 10, Seed: 42}
+
+	first, err := simulation.Simulate([]options.OptionsContract{call}, market, now, config)
+	assert.NoError(t, err)
+	second, err := simulation.Simulate([]options.OptionsContract{call}, market, now, config)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	config.Seed = 43
+	third, err := simulation.Simulate([]options.OptionsContract{call}, market, now, config)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, third)
+}
+
+func TestSimulateLongCall(t *testing.T) {
+	result, err := simulation.Simulate([]options.OptionsContract{call}, market, now, simulation.Config{Paths: 20000, Steps: 4, Seed: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 20000, result.Paths)
+
+	// the call is bought at its theoretical value. it grows at the risk free rate
+	assert.InDelta(t, 53.64, result.Mean, 25)
+
+	// the loss of a long call is limited to its premium
+	assert.Equal(t, simulation.Percentile{Percentile: 1, Value: -1045}, result.Percentiles[0])
+	assert.Equal(t, 1045.0, result.ValueAtRisk)
+	assert.Equal(t, 1045.0, result.ConditionalValueAtRisk)
+	assert.GreaterOrEqual(t, result.Mean, result.MeanWorstProfitOrLoss)
+
+	// more than half of the calls expire worthless
+	assert.Equal(t, 50.0, result.Percentiles[4].Percentile)
+	assert.Less(t, result.Percentiles[4].Value, 0.0)
+
+	count := 0
+	for _, bin := range result.Histogram {
+		assert.Less(t, bin.From, bin.To)
+		count += bin.Count
+	}
+	assert.Len(t, result.Histogram, simulation.DefaultBins)
+	assert.Equal(t, 20000, count)
+}
+
+func TestSimulateJumps(t *testing.T) {
+	contracts := []options.OptionsContract{{
+		OptionsType: "Stock",
+		EntryPrice:  100,
+		LongShort:   "long",
+		Quantity:    100,
+	}}
+	config := simulation.Config{Paths: 20000, Steps: 10, Seed: 1, Horizon: now.AddDate(1, 0, 0)}
+
+	diffusion, err := simulation.Simulate(contracts, market, now, config)
+	assert.NoError(t, err)
+
+	config.Jumps = &simulation.Jumps{Intensity: 1, Mean: -0.1, StdDev: 0.1}
+	jumps, err := simulation.Simulate(contracts, market, now, config)
+	assert.NoError(t, err)
+
+	// both grow at the risk free rate
+	expected := 100 * 100 * (math.Exp(0.05) - 1)
+	assert.InDelta(t, expected, diffusion.Mean, 50)
+	assert.InDelta(t, expected, jumps.Mean, 50)
+	// downward jumps fatten the left tail
+	assert.Greater(t, jumps.ValueAtRisk, diffusion.ValueAtRisk)
+}
+
+func TestSimulateBounds(t *testing.T) {
+	contracts := []options.OptionsContract{call}
+
+	_, err := simulation.Simulate(contracts, market, now, simulation.Config{Paths: simulation.MaxPaths + 1})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationPaths)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Paths: simulation.MaxPaths, Steps: simulation.MaxSteps})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationPaths)
+
+	// an American option costs a binomial tree at every step
+	american := call
+	american.ExerciseStyle = options.AMERICAN
+	_, err = simulation.Simulate([]options.OptionsContract{american}, market, now, simulation.Config{})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationPaths)
+	_, err = simulation.Simulate([]options.OptionsContract{american}, market, now, simulation.Config{Paths: 1000, Steps: 10})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationPaths)
+	_, err = simulation.Simulate([]options.OptionsContract{american}, market, now, simulation.Config{Paths: 20, Steps: 4})
+	assert.NoError(t, err)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Steps: -1})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationSteps)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Bins: simulation.MaxBins + 1})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationBins)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Confidence: 1})
+	assert.ErrorIs(t, err, errors.ErrInvalidConfidence)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Horizon: now})
+	assert.ErrorIs(t, err, errors.ErrInvalidSimulationHorizon)
+
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Jumps: &simulation.Jumps{Intensity: -1}})
+	assert.ErrorIs(t, err, errors.ErrInvalidJumps)
+	// without the bound, 0 * Inf puts NaN in the drift
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Jumps: &simulation.Jumps{Intensity: 0, Mean: 1000}})
+	assert.ErrorIs(t, err, errors.ErrInvalidJumps)
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Jumps: &simulation.Jumps{Intensity: 1e6}})
+	assert.ErrorIs(t, err, errors.ErrInvalidJumps)
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Jumps: &simulation.Jumps{Intensity: 1, StdDev: math.NaN()}})
+	assert.ErrorIs(t, err, errors.ErrInvalidJumps)
+	_, err = simulation.Simulate(contracts, market, now, simulation.Config{Paths: 100, Jumps: &simulation.Jumps{Intensity: simulation.MaxJumpIntensity, Mean: -simulation.MaxJumpSize, StdDev: simulation.MaxJumpSize}})
+	assert.NoError(t, err)
+
+	_, err = simulation.Simulate(contracts, pricing.Market{Spot: 100}, now, simulation.Config{})
+	assert.ErrorIs(t, err, errors.ErrInvalidVolatility)
+}
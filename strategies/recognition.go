@@ -0,0 +1,214 @@
+package strategies
+
+import (
+	"sort"
+
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// Custom is the name of a strategy that is not recognized
+const Custom = "custom"
+
+// a leg reduced to what tells strategies apart
+type position struct {
+	optionsType options.OptionsType
+	long        bool
+	strike      float64
+	shares      int
+	contract    options.OptionsContract
+}
+
+// Recognize names the strategy of the contracts, e.g. bull-call-spread or short-strangle.
+// the legs of a strategy are for the same number of shares, or in its ratios
+func Recognize(contracts []options.OptionsContract) string {
+	stocks := []position{}
+	opts := []position{}
+	for _, c := range contracts {
+		p := position{c.OptionsType.Value(), c.LongShort.Value() == options.LONG, c.StrikePrice, c.Shares(), c}
+		if p.optionsType == options.STOCK {
+			stocks = append(stocks, p)
+			continue
+		}
+		opts = append(opts, p)
+	}
+
+	// puts before calls at the same strike
+	sort.SliceStable(opts, func(i, j int) bool {
+		if opts[i].strike != opts[j].strike {
+			return opts[i].strike < opts[j].strike
+		}
+		return opts[i].optionsType == options.PUT && opts[j].optionsType == options.CALL
+	})
+
+	switch {
+	case len(stocks) == 0 && hasMixedExpirations(opts):
+		return recognizeTimeSpread(opts)
+	case len(stocks) == 0:
+		return recognizeOptions(opts)
+	case len(stocks) == 1 && !hasMixedExpirations(opts):
+		return recognizeWithStock(stocks[0], opts)
+	}
+	return Custom
+}
+
+func recognizeOptions(opts []position) string {
+	switch len(opts) {
+	case 1:
+		return single(opts[0])
+	case 2:
+		return recognizeTwoLegs(opts[0], opts[1])
+	case 3:
+		return recognizeButterfly(opts)
+	case 4:
+		return recognizeFourLegs(opts)
+	}
+	return Custom
+}
+
+func single(p position) string {
+	return direction(p.long) + "-" + string(p.optionsType)
+}
+
+// the legs are sorted by strike
+func recognizeTwoLegs(a, b position) string {
+	if a.optionsType == b.optionsType && a.long != b.long && a.strike < b.strike {
+		if a.shares != b.shares {
+			return string(a.optionsType) + "-ratio-spread"
+		}
+		bullish := a.long
+		if a.optionsType == options.PUT {
+			// a put spread is bullish when the higher strike is sold
+			bullish = !b.long
+		}
+		if bullish {
+			return "bull-" + string(a.optionsType) + "-spread"
+		}
+		return "bear-" + string(a.optionsType) + "-spread"
+	}
+
+	if a.shares != b.shares || a.long != b.long || a.optionsType != options.PUT || b.optionsType != options.CALL {
+		return Custom
+	}
+	if a.strike == b.strike {
+		return direction(a.long) + "-straddle"
+	}
+	return direction(a.long) + "-strangle"
+}
+
+// wings of one contract and a body of two, at evenly spaced strikes
+func recognizeButterfly(opts []position) string {
+	low, body, high := opts[0], opts[1], opts[2]
+	if low.optionsType != body.optionsType || body.optionsType != high.optionsType {
+		return Custom
+	}
+	if low.long != high.long || low.long == body.long || low.shares != high.shares || body.shares != 2*low.shares {
+		return Custom
+	}
+	if low.strike == body.strike || body.strike-low.strike != high.strike-body.strike {
+		return Custom
+	}
+	return direction(low.long) + "-" + string(low.optionsType) + "-butterfly"
+}
+
+// iron condors and iron butterflies are a put spread below a call spread. condors are four calls or four puts
+func recognizeFourLegs(opts []position) string {
+	for _, p := range opts[1:] {
+		if p.shares != opts[0].shares {
+			return Custom
+		}
+	}
+	wings := opts[0].long == opts[3].long && opts[1].long == opts[2].long && opts[0].long != opts[1].long
+	if !wings || opts[0].strike == opts[1].strike || opts[2].strike == opts[3].strike {
+		return Custom
+	}
+
+	prefix := ""
+	if !opts[0].long {
+		prefix = "reverse-"
+	}
+
+	types := []options.OptionsType{opts[0].optionsType, opts[1].optionsType, opts[2].optionsType, opts[3].optionsType}
+	switch {
+	case types[0] == options.PUT && types[1] == options.PUT && types[2] == options.CALL && types[3] == options.CALL:
+		if opts[1].strike == opts[2].strike {
+			return prefix + "iron-butterfly"
+		}
+		return prefix + "iron-condor"
+	case types[0] == types[1] && types[1] == types[2] && types[2] == types[3] && opts[1].strike < opts[2].strike:
+		return direction(opts[0].long) + "-" + string(types[0]) + "-condor"
+	}
+	return Custom
+}
+
+// the options of calendar and diagonal spreads expire at different dates
+func recognizeTimeSpread(opts []position) string {
+	if len(opts) != 2 {
+		return Custom
+	}
+	a, b := opts[0], opts[1]
+	if a.optionsType != b.optionsType || a.long == b.long || a.shares != b.shares {
+		return Custom
+	}
+
+	// long calendar and diagonal spreads buy the options expiring later
+	later := a
+	if b.contract.ExpirationDate.After(a.contract.ExpirationDate) {
+		later = b
+	}
+	if a.strike == b.strike {
+		return direction(later.long) + "-" + string(a.optionsType) + "-calendar-spread"
+	}
+	return direction(later.long) + "-" + string(a.optionsType) + "-diagonal-spread"
+}
+
+// options covering, or protecting, the same number of shares
+func recognizeWithStock(stock position, opts []position) string {
+	if len(opts) == 0 {
+		return single(stock)
+	}
+	for _, p := range opts {
+		if p.shares != stock.shares {
+			return Custom
+		}
+	}
+
+	if len(opts) == 1 {
+		p := opts[0]
+		switch {
+		case stock.long && p.optionsType == options.CALL && !p.long:
+			return "covered-call"
+		case stock.long && p.optionsType == options.PUT && p.long:
+			return "protective-put"
+		case !stock.long && p.optionsType == options.PUT && !p.long:
+			return "covered-put"
+		case !stock.long && p.optionsType == options.CALL && p.long:
+			return "protective-call"
+		}
+		return Custom
+	}
+
+	put, call := opts[0], opts[1]
+	if len(opts) == 2 && stock.long && put.optionsType == options.PUT && put.long && call.optionsType == options.CALL && !call.long {
+		return "collar"
+	}
+	return Custom
+}
+
+func hasMixedExpirations(opts []position) bool {
+	if len(opts) == 0 {
+		return false
+	}
+	for _, p := range opts[1:] {
+		if !p.contract.ExpirationDate.Equal(opts[0].contract.ExpirationDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func direction(long bool) string {
+	if long {
+		return "long"
+	}
+	return "short"
+}
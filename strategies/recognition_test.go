@@ -0,0 +1,84 @@
+package strategies_test
+
+import (
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/strategies"
+	"github.com/stretchr/testify/assert"
+)
+
+func leg(optionsType options.OptionsType, longShort options.LongShort, strike float64) options.OptionsContract {
+	return options.OptionsContract{
+		OptionsType:    optionsType,
+		LongShort:      longShort,
+		StrikePrice:    strike,
+		Bid:            1,
+		Ask:            1.2,
+		ExpirationDate: expirationDate,
+	}
+}
+
+func TestRecognize(t *testing.T) {
+	call, put := options.CALL, options.PUT
+	long, short := options.LONG, options.SHORT
+	stock := options.OptionsContract{OptionsType: "Stock", LongShort: "long", EntryPrice: 100, Quantity: 100}
+	twice := func(c options.OptionsContract) options.OptionsContract {
+		c.Quantity = 2
+		return c
+	}
+	later := func(c options.OptionsContract) options.OptionsContract {
+		c.ExpirationDate = c.ExpirationDate.AddDate(0, 1, 0)
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		contracts []options.OptionsContract
+	}{
+		{"long-call", []options.OptionsContract{leg(call, long, 100)}},
+		{"short-put", []options.OptionsContract{leg(put, "Short", 100)}},
+		{"bull-call-spread", []options.OptionsContract{leg(call, short, 110), leg(call, long, 100)}},
+		{"bear-call-spread", []options.OptionsContract{leg(call, short, 100), leg(call, long, 110)}},
+		{"bull-put-spread", []options.OptionsContract{leg(put, long, 100), leg(put, short, 110)}},
+		{"bear-put-spread", []options.OptionsContract{leg(put, short, 100), leg(put, long, 110)}},
+		{"call-ratio-spread", []options.OptionsContract{leg(call, long, 100), twice(leg(call, short, 110))}},
+		{"long-straddle", []options.OptionsContract{leg(call, long, 100), leg(put, long, 100)}},
+		{"short-strangle", []options.OptionsContract{leg(call, short, 110), leg(put, short, 90)}},
+		{"long-call-butterfly", []options.OptionsContract{leg(call, long, 90), twice(leg(call, short, 100)), leg(call, long, 110)}},
+		{"short-put-butterfly", []options.OptionsContract{leg(put, short, 90), twice(leg(put, long, 100)), leg(put, short, 110)}},
+		{"iron-condor", []options.OptionsContract{leg(put, long, 80), leg(put, short, 90), leg(call, short, 110), leg(call, long, 120)}},
+		{"reverse-iron-condor", []options.OptionsContract{leg(put, short, 80), leg(put, long, 90), leg(call, long, 110), leg(call, short, 120)}},
+		{"iron-butterfly", []options.OptionsContract{leg(put, long, 90), leg(put, short, 100), leg(call, short, 100), leg(call, long, 110)}},
+		{"long-call-condor", []options.OptionsContract{leg(call, long, 80), leg(call, short, 90), leg(call, short, 110), leg(call, long, 120)}},
+		{"long-call-calendar-spread", []options.OptionsContract{leg(call, short, 100), later(leg(call, long, 100))}},
+		{"short-put-diagonal-spread", []options.OptionsContract{leg(put, long, 100), later(leg(put, short, 90))}},
+		{"long-stock", []options.OptionsContract{stock}},
+		{"covered-call", []options.OptionsContract{stock, leg(call, short, 110)}},
+		{"protective-put", []options.OptionsContract{stock, leg(put, long, 90)}},
+		{"collar", []options.OptionsContract{stock, leg(call, short, 110), leg(put, long, 90)}},
+		// not evenly spaced
+		{strategies.Custom, []options.OptionsContract{leg(call, long, 90), twice(leg(call, short, 100)), leg(call, long, 120)}},
+		// not for the same number of shares
+		{strategies.Custom, []options.OptionsContract{leg(call, long, 100), twice(leg(put, long, 100))}},
+		{strategies.Custom, []options.OptionsContract{twice(stock), leg(call, short, 110)}},
+		{strategies.Custom, []options.OptionsContract{}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.name, strategies.Recognize(test.contracts), "%+v", test.contracts)
+	}
+}
+
+func TestRecognizeTemplates(t *testing.T) {
+	template, err := strategies.Find("iron-condor")
+	assert.NoError(t, err)
+	legs, err := template.Build(strategies.Parameters{
+		Strike:         100,
+		Width:          10,
+		Quotes:         []strategies.Quote{{1, 1.2}, {2, 2.2}, {2.1, 2.3}, {1.1, 1.3}},
+		ExpirationDate: expirationDate,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "iron-condor", strategies.Recognize(legs))
+}
@@ -0,0 +1,233 @@
+// strategy templates expand to the legs of common options strategies
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	appErrors "github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+)
+
+// Template represents an options strategy. its legs are bought as listed, or sold when the strategy is short
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// number of distinct strikes, from the lowest
+	Strikes int   `json:"strikes"`
+	Legs    []Leg `json:"legs"`
+}
+
+// Leg represents a leg of a template. a leg without type takes the type of the parameters
+type Leg struct {
+	OptionsType options.OptionsType `json:"type,omitempty"`
+	LongShort   options.LongShort   `json:"long_short"`
+	// index of the strike of the leg. stock legs have none
+	Strike int `json:"strike"`
+	// number of contracts per unit of the strategy
+	Ratio int `json:"ratio"`
+}
+
+// Parameters represents the inputs of a template
+type Parameters struct {
+	// the strikes of the template, from the lowest. or a center strike and the width between strikes
+	Strikes []float64 `json:"strikes,omitempty"`
+	Strike  float64   `json:"strike,omitempty"`
+	Width   float64   `json:"width,omitempty"`
+	// bid and ask of every option leg, in the order of the legs of the template
+	Quotes         []Quote   `json:"quotes"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	// defaults to long. a short strategy sells the legs bought by the template and buys the legs it sells
+	LongShort options.LongShort `json:"long_short,omitempty"`
+	// number of units of the strategy. defaults to 1
+	Quantity      int                   `json:"quantity,omitempty"`
+	Multiplier    int                   `json:"multiplier,omitempty"`
+	ExerciseStyle options.ExerciseStyle `json:"exercise_style,omitempty"`
+	// type of the legs without type, e.g. of a butterfly. defaults to call
+	OptionsType options.OptionsType `json:"type,omitempty"`
+	// entry price of the stock leg, e.g. of a collar
+	EntryPrice float64 `json:"entry_price,omitempty"`
+}
+
+// Quote represents the bid and ask prices of an option leg
+type Quote struct {
+	Bid float64 `json:"bid"`
+	Ask float64 `json:"ask"`
+}
+
+// Parameter describes a parameter for the catalog
+type Parameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+var (
+	call  = options.CALL
+	put   = options.PUT
+	stock = options.STOCK
+	long  = options.LONG
+	short = options.SHORT
+)
+
+var templates = []Template{
+	{"bull-call-spread", "buys a call and sells a call with a higher strike", 2, []Leg{{call, long, 0, 1}, {call, short, 1, 1}}},
+	{"bear-call-spread", "sells a call and buys a call with a higher strike", 2, []Leg{{call, short, 0, 1}, {call, long, 1, 1}}},
+	{"bull-put-spread", "buys a put and sells a put with a higher strike", 2, []Leg{{put, long, 0, 1}, {put, short, 1, 1}}},
+	{"bear-put-spread", "sells a put and buys a put with a higher strike", 2, []Leg{{put, short, 0, 1}, {put, long, 1, 1}}},
+	{"straddle", "buys a call and a put with the same strike", 1, []Leg{{call, long, 0, 1}, {put, long, 0, 1}}},
+	{"strangle", "buys a put and a call with a higher strike", 2, []Leg{{put, long, 0, 1}, {call, long, 1, 1}}},
+	{"butterfly", "buys the wings and sells two contracts of the body. calls unless the type is given", 3, []Leg{{"", long, 0, 1}, {"", short, 1, 2}, {"", long, 2, 1}}},
+	{"iron-condor", "sells a put spread and a call spread, for a credit", 4, []Leg{{put, long, 0, 1}, {put, short, 1, 1}, {call, short, 2, 1}, {call, long, 3, 1}}},
+	{"collar", "holds the shares of the underlying, buys a put and sells a call with a higher strike", 2, []Leg{{stock, long, -1, 1}, {put, long, 0, 1}, {call, short, 1, 1}}},
+}
+
+// ParameterCatalog lists the parameters that all templates accept
+var ParameterCatalog = []Parameter{
+	{"strikes", "the strikes of the template, from the lowest", false},
+	{"strike", "the center strike, with width. when the strikes are not given", false},
+	{"width", "the width between consecutive strikes, with strike", false},
+	{"quotes", "bid and ask of every option leg, in the order of the legs", true},
+	{"expiration_date", "the expiration date of all options", true},
+	{"long_short", "long buys the strategy as listed, short sells it. defaults to long", false},
+	{"quantity", "number of units of the strategy. defaults to 1", false},
+	{"multiplier", "number of shares of the underlying per contract", false},
+	{"exercise_style", "european or american. defaults to european", false},
+	{"type", "call or put, for legs without type. defaults to call", false},
+	{"entry_price", "entry price of the stock leg", false},
+}
+
+// Templates returns the supported templates, by name
+func Templates() []Template {
+	sorted := append([]Template{}, templates...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// Find returns the template of the given name
+func Find(name string) (Template, error) {
+	for _, t := range templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Template{}, fmt.Errorf("%w: %q", appErrors.ErrUnknownStrategy, name)
+}
+
+// Build expands the template into its contracts. the contracts are validated with the analysis
+func (t Template) Build(p Parameters) ([]options.OptionsContract, error) {
+	strikes, err := t.strikes(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.Quotes) != t.optionLegs() {
+		return nil, fmt.Errorf("%w: got %d quotes, expected %d", appErrors.ErrInvalidQuotes, len(p.Quotes), t.optionLegs())
+	}
+
+	direction := p.LongShort.Value()
+	if direction == "" {
+		direction = long
+	}
+	if err := direction.IsValid(); err != nil {
+		return nil, err
+	}
+
+	quantity := p.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity < 0 || quantity > options.MaxShares {
+		return nil, appErrors.ErrInvalidQuantity
+	}
+	// the shares of the contracts are validated with the contracts. the bounds keep their products from overflowing
+	if p.Multiplier < 0 || p.Multiplier > options.MaxShares {
+		return nil, appErrors.ErrInvalidMultiplier
+	}
+
+	optionsType := p.OptionsType.Value()
+	if optionsType == "" {
+		optionsType = call
+	}
+	if optionsType != call && optionsType != put {
+		return nil, appErrors.ErrInvalidOptionsType
+	}
+
+	contracts := []options.OptionsContract{}
+	quotes := p.Quotes
+	for _, leg := range t.Legs {
+		c := options.OptionsContract{
+			OptionsType: leg.OptionsType,
+			LongShort:   leg.LongShort,
+			Quantity:    leg.Ratio * quantity,
+		}
+		if direction == short {
+			c.LongShort = reverse(leg.LongShort)
+		}
+
+		if leg.OptionsType == stock {
+			// as many shares as the options are for
+			multiplier := p.Multiplier
+			if multiplier == 0 {
+				multiplier = options.DefaultMultiplier
+			}
+			c.EntryPrice = p.EntryPrice
+			c.Quantity *= multiplier
+			contracts = append(contracts, c)
+			continue
+		}
+
+		if c.OptionsType == "" {
+			c.OptionsType = optionsType
+		}
+		c.StrikePrice = strikes[leg.Strike]
+		c.Bid, c.Ask = quotes[0].Bid, quotes[0].Ask
+		c.ExpirationDate = p.ExpirationDate
+		c.Multiplier = p.Multiplier
+		c.ExerciseStyle = p.ExerciseStyle
+		quotes = quotes[1:]
+		contracts = append(contracts, c)
+	}
+	return contracts, nil
+}
+
+// the given strikes, or strikes spaced by the width around the center strike
+func (t Template) strikes(p Parameters) ([]float64, error) {
+	strikes := p.Strikes
+	if len(strikes) == 0 {
+		if p.Strike <= 0 || (t.Strikes > 1 && p.Width <= 0) {
+			return nil, fmt.Errorf("%w: expected %d strikes, or a strike and a width", appErrors.ErrInvalidStrikePrice, t.Strikes)
+		}
+		for i := 0; i < t.Strikes; i++ {
+			strikes = append(strikes, p.Strike+(float64(i)-float64(t.Strikes-1)/2)*p.Width)
+		}
+	}
+
+	if len(strikes) != t.Strikes {
+		return nil, fmt.Errorf("%w: got %d strikes, expected %d", appErrors.ErrInvalidStrikePrice, len(strikes), t.Strikes)
+	}
+	for i, strike := range strikes {
+		if strike <= 0 || (i > 0 && strike <= strikes[i-1]) {
+			return nil, fmt.Errorf("%w: strikes must be positive and increasing", appErrors.ErrInvalidStrikePrice)
+		}
+	}
+	return strikes, nil
+}
+
+func (t Template) optionLegs() int {
+	legs := 0
+	for _, leg := range t.Legs {
+		if leg.OptionsType != stock {
+			legs++
+		}
+	}
+	return legs
+}
+
+func reverse(l options.LongShort) options.LongShort {
+	if l == long {
+		return short
+	}
+	return long
+}
@@ -0,0 +1,108 @@
+package strategies_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aries-financial-inc/options-service/errors"
+	"github.com/aries-financial-inc/options-service/options"
+	"github.com/aries-financial-inc/options-service/strategies"
+	"github.com/stretchr/testify/assert"
+)
+
+var expirationDate = time.Date(2099, 12, 17, 0, 0, 0, 0, time.UTC)
+
+func TestBuildIronCondor(t *testing.T) {
+	template, err := strategies.Find("iron-condor")
+	assert.NoError(t, err)
+
+	contracts, err := template.Build(strategies.Parameters{
+		Strike:         100,
+		Width:          10,
+		Quotes:         []strategies.Quote{{1, 1.2}, {2, 2.2}, {2.1, 2.3}, {1.1, 1.3}},
+		ExpirationDate: expirationDate,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []options.OptionsContract{
+		{OptionsType: options.PUT, StrikePrice: 85, Bid: 1, Ask: 1.2, ExpirationDate: expirationDate, LongShort: options.LONG, Quantity: 1},
+		{OptionsType: options.PUT, StrikePrice: 95, Bid: 2, Ask: 2.2, ExpirationDate: expirationDate, LongShort: options.SHORT, Quantity: 1},
+		{OptionsType: options.CALL, StrikePrice: 105, Bid: 2.1, Ask: 2.3, ExpirationDate: expirationDate, LongShort: options.SHORT, Quantity: 1},
+		{OptionsType: options.CALL, StrikePrice: 115, Bid: 1.1, Ask: 1.3, ExpirationDate: expirationDate, LongShort: options.LONG, Quantity: 1},
+	}, contracts)
+}
+
+func TestBuildShortPutButterfly(t *testing.T) {
+	template, err := strategies.Find("butterfly")
+	assert.NoError(t, err)
+
+	contracts, err := template.Build(strategies.Parameters{
+		Strikes:        []float64{90, 100, 110},
+		Quotes:         []strategies.Quote{{1, 1.2}, {4, 4.2}, {11, 11.4}},
+		ExpirationDate: expirationDate,
+		LongShort:      "Short",
+		Quantity:       2,
+		OptionsType:    "put",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, contracts, 3)
+	for i, c := range contracts {
+		assert.Equal(t, options.PUT, c.OptionsType)
+		assert.Equal(t, []float64{90, 100, 110}[i], c.StrikePrice)
+		assert.Equal(t, []options.LongShort{options.SHORT, options.LONG, options.SHORT}[i], c.LongShort)
+		assert.Equal(t, []int{2, 4, 2}[i], c.Quantity)
+	}
+}
+
+func TestBuildCollar(t *testing.T) {
+	template, err := strategies.Find("collar")
+	assert.NoError(t, err)
+
+	contracts, err := template.Build(strategies.Parameters{
+		Strikes:        []float64{95, 110},
+		Quotes:         []strategies.Quote{{2, 2.2}, {1.5, 1.7}},
+		ExpirationDate: expirationDate,
+		EntryPrice:     100,
+		Quantity:       3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, options.OptionsContract{OptionsType: options.STOCK, EntryPrice: 100, LongShort: options.LONG, Quantity: 300}, contracts[0])
+	assert.Equal(t, 95.0, contracts[1].StrikePrice)
+	assert.Equal(t, 110.0, contracts[2].StrikePrice)
+	// as many shares as the options are for
+	assert.Equal(t, contracts[0].Shares(), contracts[1].Shares())
+}
+
+func TestBuildErrors(t *testing.T) {
+	template, err := strategies.Find("bull-call-spread")
+	assert.NoError(t, err)
+	quotes := []strategies.Quote{{5, 5.2}, {2, 2.2}}
+
+	_, err = template.Build(strategies.Parameters{Strikes: []float64{100}, Quotes: quotes})
+	assert.ErrorIs(t, err, errors.ErrInvalidStrikePrice)
+
+	_, err = template.Build(strategies.Parameters{Strikes: []float64{110, 100}, Quotes: quotes})
+	assert.ErrorIs(t, err, errors.ErrInvalidStrikePrice)
+
+	_, err = template.Build(strategies.Parameters{Strike: 100, Quotes: quotes})
+	assert.ErrorIs(t, err, errors.ErrInvalidStrikePrice)
+
+	_, err = template.Build(strategies.Parameters{Strikes: []float64{100, 110}, Quotes: quotes[:1]})
+	assert.ErrorIs(t, err, errors.ErrInvalidQuotes)
+
+	_, err = template.Build(strategies.Parameters{Strikes: []float64{100, 110}, Quotes: quotes, LongShort: "flat"})
+	assert.ErrorIs(t, err, errors.ErrInvalidLongShort)
+
+	_, err = strategies.Find("jade-lizard")
+	assert.ErrorIs(t, err, errors.ErrUnknownStrategy)
+}
+
+func TestTemplates(t *testing.T) {
+	for _, template := range strategies.Templates() {
+		assert.NotEmpty(t, template.Description, template.Name)
+		for _, leg := range template.Legs {
+			if leg.OptionsType != options.STOCK {
+				assert.Less(t, leg.Strike, template.Strikes, template.Name)
+			}
+		}
+	}
+}
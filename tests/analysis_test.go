@@ -3,14 +3,17 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/aries-financial-inc/options-service/controllers"
+	appErrors "github.com/aries-financial-inc/options-service/errors"
 	"github.com/aries-financial-inc/options-service/options"
 	"github.com/aries-financial-inc/options-service/routes"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +70,28 @@ func TestOptionsContractModelValidation(t *testing.T) {
 		}.IsValid(), errors.ErrInvalidLongShort)
 	})
 
+	t.Run("invalid quantity", func(t *testing.T) {
+		assert.ErrorIs(t, options.OptionsContract{
+			OptionsType: options.CALL,
+			StrikePrice: 100.0,
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   options.LONG,
+			Quantity:    -1,
+		}.IsValid(), errors.ErrInvalidQuantity)
+	})
+
+	t.Run("invalid multiplier", func(t *testing.T) {
+		assert.ErrorIs(t, options.OptionsContract{
+			OptionsType: options.CALL,
+			StrikePrice: 100.0,
+			Bid:         10.05,
+			Ask:         12.04,
+			LongShort:   options.LONG,
+			Multiplier:  -100,
+		}.IsValid(), errors.ErrInvalidMultiplier)
+	})
+
 	t.Run("invalid expiration date", func(t *testing.T) {
 		assert.ErrorIs(t, options.OptionsContract{
 			OptionsType: options.CALL,
@@ -87,8 +112,30 @@ func TestOptionsContractModelValidation(t *testing.T) {
 		}.IsValid(), errors.ErrInvalidExpirationDate)
 	})
 
+	t.Run("invalid stock", func(t *testing.T) {
+		assert.ErrorIs(t, options.OptionsContract{
+			OptionsType: options.STOCK,
+			LongShort:   options.LONG,
+		}.IsValid(), errors.ErrInvalidEntryPrice)
+
+		assert.ErrorIs(t, options.OptionsContract{
+			OptionsType: options.STOCK,
+			EntryPrice:  100.0,
+		}.IsValid(), errors.ErrInvalidLongShort)
+	})
+
+	t.Run("valid stock", func(t *testing.T) {
+		// stock has no strike price, bid, ask or expiration date
+		assert.NoError(t, options.OptionsContract{
+			OptionsType: options.STOCK,
+			EntryPrice:  100.0,
+			LongShort:   options.LONG,
+			Quantity:    100,
+		}.IsValid())
+	})
+
 	t.Run("valid options contract", func(t *testing.T) {
-		expirationDate, err := time.Parse(time.RFC3339, "2025-12-17T00:00:00Z")
+		expirationDate, err := time.Parse(time.RFC3339, "2099-12-17T00:00:00Z")
 		assert.NoError(t, err)
 		assert.NoError(t, options.OptionsContract{
 			OptionsType:    options.CALL,
@@ -111,9 +158,82 @@ func TestAnalysisEndpoint(t *testing.T) {
 		res := httptest.NewRecorder()
 		controllers.AnalysisHandler(res, req)
 		assert.Equal(t, http.StatusBadRequest, res.Code)
+
+		req, err = http.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`[]`))
+		assert.NoError(t, err)
+		res = httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, `{
+			"type": "/problems/invalid-request",
+			"title": "Invalid request",
+			"status": 400,
+			"code": "invalid_request",
+			"detail": "contracts: invalid number of options contracts: got 0, expected 1 to 4",
+			"errors": [
+				{
+					"field": "contracts",
+					"code": "invalid_number_of_contracts",
+					"message": "invalid number of options contracts: got 0, expected 1 to 4"
+				}
+			]
+		}`, res.Body.String())
+	})
+
+	t.Run("all errors of all legs", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`{
+			"contracts": [
+				{
+					"strike_price": 100,
+					"type": "Call",
+					"bid": 10.05,
+					"ask": 12.04,
+					"long_short": "long",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				},
+				{
+					"strike_price": -5,
+					"type": "Put",
+					"bid": 14,
+					"ask": 12,
+					"long_short": "sideways",
+					"expiration_date": "2099-12-17T00:00:00Z"
+				}
+			],
+			"market": {"spot": 0, "volatility": 0.2}
+		}`))
+		assert.NoError(t, err)
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, "application/problem+json", res.Header().Get("Content-Type"))
+
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		leg := 1
+		assert.Equal(t, []controllers.ProblemError{
+			{Leg: &leg, Field: "strike_price", Code: "invalid_strike_price", Message: "invalid strike price"},
+			{Leg: &leg, Field: "ask", Code: "ask_bid_mismatch", Message: "ask price must be greater than bid price"},
+			{Leg: &leg, Field: "long_short", Code: "invalid_long_short", Message: "invalid longShort"},
+			{Field: "market.spot", Code: "invalid_spot_price", Message: "invalid spot price"},
+		}, problem.Errors)
+	})
+
+	t.Run("error on malformed request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`{"contracts": [`))
+		assert.NoError(t, err)
+		res := httptest.NewRecorder()
+		controllers.AnalysisHandler(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+
+		problem := controllers.Problem{}
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &problem))
+		assert.Equal(t, "/problems/malformed-request", problem.Type)
+		assert.Equal(t, appErrors.CodeMalformedRequest, problem.Code)
+		assert.Equal(t, "unexpected end of JSON input", problem.Detail)
 	})
 
-	t.Run("error on less than 4 options", func(t *testing.T) {
+	t.Run("analysis of less than 4 options", func(t *testing.T) {
 		req, err := http.NewRequest(http.MethodPost, "/analyze", strings.NewReader(`[
   		{
    			"strike_price": 100, 
@@ -121,12 +241,12 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		}]`))
 		assert.NoError(t, err)
 		res := httptest.NewRecorder()
 		controllers.AnalysisHandler(res, req)
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusOK, res.Code)
 	})
 
 	t.Run("error on more than 4 options", func(t *testing.T) {
@@ -137,7 +257,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -145,7 +265,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -153,7 +273,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -161,7 +281,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -169,7 +289,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		}]`))
 		assert.NoError(t, err)
 		res := httptest.NewRecorder()
@@ -186,7 +306,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -194,7 +314,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -202,7 +322,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
 		{
    			"strike_price": 100, 
@@ -210,7 +330,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		}]`))
 		assert.NoError(t, err)
 		res := httptest.NewRecorder()
@@ -227,7 +347,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 102.50, 
@@ -235,7 +355,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 12.10, 
     		"ask": 14, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 103, 
@@ -243,7 +363,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 14, 
     		"ask": 15.50, 
     		"long_short": "short", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 105, 
@@ -251,7 +371,7 @@ func TestAnalysisEndpoint(t *testing.T) {
     		"bid": 16, 
     		"ask": 18, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		}]`))
 		assert.NoError(t, err)
 		res := httptest.NewRecorder()
@@ -269,78 +389,62 @@ func TestAnalysisEndpoint(t *testing.T) {
 		{
     		"xy_values": [
 				{
-                    "x": 100,
-                    "y": -12.04
+                    "x": 0,
+                    "y": -2804
                 },
                 {
-                    "x": 112.04,
-                    "y": 0
+                    "x": 100,
+                    "y": -2804
                 },
                 {
                     "x": 102.50,
-                    "y": -14
-                },
-                {
-                    "x": 116.5,
-                    "y": 0
+                    "y": -2554
                 },
                 {
                     "x": 103,
-                    "y": 14
-                },
-                {
-                    "x": 89,
-                    "y": 0
+                    "y": -2454
                 },
                 {
                     "x": 105,
-                    "y": -18
+                    "y": -2254
                 },
                 {
-                    "x": 87,
+                    "x": 116.27,
                     "y": 0
                 },
-                {
-                    "x": 0,
-                    "y": -12.04
-                },
-                {
-                    "x": 210,
-                    "y": 97.96
-                },
-                {
-                    "x": 0,
-                    "y": -14
-                },
                 {
                     "x": 210,
-                    "y": 93.50
-                },
-                {
-                    "x": 0,
-                    "y": -89
-                },
-                {
-                    "x": 210,
-                    "y": 14
-                },
-                {
-                    "x": 0,
-                    "y": 87
-                },
-                {
-                    "x": 210,
-                    "y": -18
+                    "y": 18746
                 }
 			],
-    		"max_profit": 97.96,
-   		 	"max_loss": -89.0,
+    		"max_profit": {
+   		 		"value": "unlimited"
+   		 	},
+   		 	"max_loss": {
+   		 		"value": -2804,
+   		 		"price": 0
+   		 	},
    			"break_even_points": [
-        		112.04,
-        		116.5,
-        		89,
-        		87
-    		]
+        		116.27
+    		],
+   			"strategy": {
+   				"name": "custom",
+   				"risk": "defined"
+   			},
+   			"margin": {
+   				"requirement": 3004,
+   				"requirements": [
+   					{"rule": "spread", "legs": [2, 3], "amount": 0},
+   					{"rule": "premium", "legs": [0, 1, 2, 3], "amount": 3004}
+   				]
+   			},
+   			"metrics": {
+   				"entry": "debit",
+   				"net_premium": 3004,
+   				"risk_reward": null,
+   				"return_on_max_loss": null,
+   				"return_on_margin": null
+   			}
 		}
 		`, (string)(resBody))
 	})
@@ -359,7 +463,7 @@ func TestIntegration(t *testing.T) {
     		"bid": 10.05, 
     		"ask": 12.04, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 102.50, 
@@ -367,7 +471,7 @@ func TestIntegration(t *testing.T) {
     		"bid": 12.10, 
     		"ask": 14, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 103, 
@@ -375,7 +479,7 @@ func TestIntegration(t *testing.T) {
     		"bid": 14, 
     		"ask": 15.50, 
     		"long_short": "short", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		},
   		{
     		"strike_price": 105, 
@@ -383,7 +487,7 @@ func TestIntegration(t *testing.T) {
     		"bid": 16, 
     		"ask": 18, 
     		"long_short": "long", 
-    		"expiration_date": "2025-12-17T00:00:00Z"
+    		"expiration_date": "2099-12-17T00:00:00Z"
   		}]
 	`
 
@@ -400,78 +504,263 @@ func TestIntegration(t *testing.T) {
 		{
     		"xy_values": [
 				{
-                    "x": 100,
-                    "y": -12.04
+                    "x": 0,
+                    "y": -2804
                 },
                 {
-                    "x": 112.04,
-                    "y": 0
+                    "x": 100,
+                    "y": -2804
                 },
                 {
                     "x": 102.50,
-                    "y": -14
-                },
-                {
-                    "x": 116.5,
-                    "y": 0
+                    "y": -2554
                 },
                 {
                     "x": 103,
-                    "y": 14
-                },
-                {
-                    "x": 89,
-                    "y": 0
+                    "y": -2454
                 },
                 {
                     "x": 105,
-                    "y": -18
+                    "y": -2254
                 },
                 {
-                    "x": 87,
+                    "x": 116.27,
                     "y": 0
                 },
-                {
-                    "x": 0,
-                    "y": -12.04
-                },
                 {
                     "x": 210,
-                    "y": 97.96
-                },
-                {
-                    "x": 0,
-                    "y": -14
-                },
-                {
-                    "x": 210,
-                    "y": 93.50
-                },
-                {
-                    "x": 0,
-                    "y": -89
-                },
-                {
-                    "x": 210,
-                    "y": 14
-                },
-                {
-                    "x": 0,
-                    "y": 87
-                },
-                {
-                    "x": 210,
-                    "y": -18
+                    "y": 18746
                 }
 			],
-    		"max_profit": 97.96,
-   		 	"max_loss": -89.0,
+    		"max_profit": {
+   		 		"value": "unlimited"
+   		 	},
+   		 	"max_loss": {
+   		 		"value": -2804,
+   		 		"price": 0
+   		 	},
    			"break_even_points": [
-        		112.04,
-        		116.5,
-        		89,
-        		87
-    		]
+        		116.27
+    		],
+   			"strategy": {
+   				"name": "custom",
+   				"risk": "defined"
+   			},
+   			"margin": {
+   				"requirement": 3004,
+   				"requirements": [
+   					{"rule": "spread", "legs": [2, 3], "amount": 0},
+   					{"rule": "premium", "legs": [0, 1, 2, 3], "amount": 3004}
+   				]
+   			},
+   			"metrics": {
+   				"entry": "debit",
+   				"net_premium": 3004,
+   				"risk_reward": null,
+   				"return_on_max_loss": null,
+   				"return_on_margin": null
+   			}
 		}
 		`, (string)(resBody))
 }
+
+func TestIntegrationNumberOfContracts(t *testing.T) {
+	router := routes.SetupRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	testdata, err := os.ReadFile("../testdata/testdata.json")
+	assert.NoError(t, err)
+	contracts := []json.RawMessage{}
+	assert.NoError(t, json.Unmarshal(testdata, &contracts))
+
+	// break even points of the first n contracts of the testdata
+	breakEvenPoints := [][]float64{
+		{112.04},
+		{114.27},
+		{107.27},
+		{116.27},
+	}
+
+	for n := 1; n <= len(contracts); n++ {
+		t.Run(fmt.Sprintf("%d contracts", n), func(t *testing.T) {
+			reqBody, err := json.Marshal(contracts[:n])
+			assert.NoError(t, err)
+
+			res, err := http.Post(server.URL+"/analyze", "application/json", bytes.NewBuffer(reqBody))
+			assert.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			resp := struct {
+				BreakEvenPoints []float64 `json:"break_even_points"`
+			}{}
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			assert.Equal(t, breakEvenPoints[n-1], resp.BreakEvenPoints)
+		})
+	}
+
+	t.Run("error on more than 4 contracts", func(t *testing.T) {
+		reqBody, err := json.Marshal(append(contracts, contracts[0]))
+		assert.NoError(t, err)
+
+		res, err := http.Post(server.URL+"/analyze", "application/json", bytes.NewBuffer(reqBody))
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestPricingIntegration(t *testing.T) {
+	router := routes.SetupRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	testdata, err := os.ReadFile("../testdata/testdata.json")
+	assert.NoError(t, err)
+	contracts := []json.RawMessage{}
+	assert.NoError(t, json.Unmarshal(testdata, &contracts))
+
+	reqBody, err := json.Marshal(map[string]any{
+		"contracts":      contracts,
+		"market":         map[string]float64{"spot": 105, "rate": 0.05, "volatility": 0.25},
+		"valuation_date": "2099-06-17T00:00:00Z",
+	})
+	assert.NoError(t, err)
+
+	res, err := http.Post(server.URL+"/price", "application/json", bytes.NewBuffer(reqBody))
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resp := controllers.PricingResponse{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Len(t, resp.Prices, len(contracts))
+	for _, p := range resp.Prices {
+		assert.Greater(t, p.TheoreticalValue, 0.0)
+	}
+	// calls are worth less at higher strike prices, puts are worth more
+	assert.Greater(t, resp.Prices[0].TheoreticalValue, resp.Prices[1].TheoreticalValue)
+	assert.Less(t, resp.Prices[2].TheoreticalValue, resp.Prices[3].TheoreticalValue)
+}
+
+func TestGreeksIntegration(t *testing.T) {
+	router := routes.SetupRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	testdata, err := os.ReadFile("../testdata/testdata.json")
+	assert.NoError(t, err)
+	contracts := []json.RawMessage{}
+	assert.NoError(t, json.Unmarshal(testdata, &contracts))
+
+	reqBody, err := json.Marshal(map[string]any{
+		"contracts":      contracts,
+		"market":         map[string]float64{"spot": 105, "rate": 0.05, "volatility": 0.25},
+		"valuation_date": "2099-06-17T00:00:00Z",
+	})
+	assert.NoError(t, err)
+
+	res, err := http.Post(server.URL+"/analyze", "application/json", bytes.NewBuffer(reqBody))
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resp := controllers.AnalysisResponse{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, []float64{116.27}, resp.BreakEvenPoints)
+	assert.NotNil(t, resp.Greeks)
+	assert.Len(t, resp.Greeks.Contracts, len(contracts))
+
+	// two long calls and a short put gain as the underlying rises. the long put loses
+	assert.Greater(t, resp.Greeks.Contracts[0].Delta, 0.0)
+	assert.Greater(t, resp.Greeks.Contracts[1].Delta, 0.0)
+	assert.Greater(t, resp.Greeks.Contracts[2].Delta, 0.0)
+	assert.Less(t, resp.Greeks.Contracts[3].Delta, 0.0)
+	assert.Greater(t, resp.Greeks.Position.Delta, 0.0)
+}
+
+func TestStrategiesIntegration(t *testing.T) {
+	router := routes.SetupRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/strategies")
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	reqBody := `{
+		"strike": 100,
+		"quotes": [{"bid": 10.05, "ask": 10.45}, {"bid": 5.4, "ask": 5.8}],
+		"expiration_date": "2099-12-17T00:00:00Z"
+	}`
+	res, err = http.Post(server.URL+"/strategies/straddle", "application/json", strings.NewReader(reqBody))
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resp := controllers.StrategyResponse{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, []float64{83.75, 116.25}, resp.BreakEvenPoints)
+	assert.True(t, resp.MaxProfit.Unlimited)
+	assert.Equal(t, controllers.Extremum{Value: -1625, Price: 100}, resp.MaxLoss)
+}
+
+func TestErrorsIntegration(t *testing.T) {
+	router := routes.SetupRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	problem := func(t *testing.T, res *http.Response) controllers.Problem {
+		defer res.Body.Close()
+		assert.Equal(t, "application/problem+json", res.Header.Get("Content-Type"))
+		problem := controllers.Problem{}
+		assert.NoError(t, json.NewDecoder(res.Body).Decode(&problem))
+		assert.Equal(t, res.StatusCode, problem.Status)
+		return problem
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		res, err := http.Post(server.URL+"/price", "application/json", strings.NewReader(`{"contracts": `))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, appErrors.CodeMalformedRequest, problem(t, res).Code)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		res, err := http.Post(server.URL+"/analyze", "application/json", strings.NewReader(`{"contracts": []}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, appErrors.CodeInvalidRequest, problem(t, res).Code)
+	})
+
+	t.Run("request too large", func(t *testing.T) {
+		body := `{"contracts": [], "padding": "` + strings.Repeat("x", int(routes.MaxRequestBytes)) + `"}`
+		res, err := http.Post(server.URL+"/analyze", "application/json", strings.NewReader(body))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+		assert.Equal(t, appErrors.CodeRequestTooLarge, problem(t, res).Code)
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		res, err := http.Post(server.URL+"/strategies/jade-lizard", "application/json", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, appErrors.CodeNotFound, problem(t, res).Code)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/portfolio")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, appErrors.CodeNotFound, problem(t, res).Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		res, err := http.Get(server.URL + "/analyze")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, appErrors.CodeMethodNotAllowed, problem(t, res).Code)
+	})
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aries-financial-inc/options-service/api"
+	"github.com/aries-financial-inc/options-service/routes"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the responses of the handlers, successful or not, conform to the OpenAPI document
+func TestOpenAPI(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData(api.OpenAPI)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(ctx))
+	specRouter, err := legacy.NewRouter(doc)
+	require.NoError(t, err)
+
+	router := routes.SetupRouter()
+
+	testdata, err := os.ReadFile("../testdata/testdata.json")
+	require.NoError(t, err)
+	contracts := string(testdata)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"analysis of bare contracts", http.MethodPost, "/v1/analyze", contracts, http.StatusOK},
+		{"analysis with market and costs", http.MethodPost, "/v1/analyze", `{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 105, "rate": 0.05, "volatility": 0.25},
+			"valuation_date": "2099-06-17T00:00:00Z",
+			"evaluation_dates": ["2099-09-17T00:00:00Z"],
+			"costs": {"commission_per_contract": 0.65, "fill": "mid"}
+		}`, http.StatusOK},
+		{"analysis with unlimited profit", http.MethodPost, "/v1/analyze", `[
+			{"strike_price": 100, "type": "call", "bid": 10.05, "ask": 12.04, "long_short": "long", "expiration_date": "2099-12-17T00:00:00Z"}
+		]`, http.StatusOK},
+		{"invalid analysis", http.MethodPost, "/v1/analyze", `{"contracts": []}`, http.StatusBadRequest},
+		{"batch", http.MethodPost, "/v1/analyze/batch", `{"items": [` + contracts + `, {"contracts": []}]}`, http.StatusOK},
+		{"pricing", http.MethodPost, "/v1/price", `{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 105, "rate": 0.05, "volatility": 0.25},
+			"valuation_date": "2099-06-17T00:00:00Z"
+		}`, http.StatusOK},
+		{"simulation", http.MethodPost, "/v1/simulate", `{
+			"contracts": ` + contracts + `,
+			"market": {"spot": 105, "rate": 0.05, "volatility": 0.25},
+			"valuation_date": "2099-06-17T00:00:00Z",
+			"simulation": {"paths": 200, "steps": 10, "seed": 1}
+		}`, http.StatusOK},
+		{"strategies", http.MethodGet, "/v1/strategies", "", http.StatusOK},
+		{"strategy", http.MethodPost, "/v1/strategies/iron-condor", `{
+			"strike": 100,
+			"width": 5,
+			"quotes": [{"bid": 1, "ask": 1.2}, {"bid": 2, "ask": 2.2}, {"bid": 2.1, "ask": 2.3}, {"bid": 1.1, "ask": 1.3}],
+			"expiration_date": "2099-12-17T00:00:00Z"
+		}`, http.StatusOK},
+		{"unknown strategy", http.MethodPost, "/v1/strategies/jade-lizard", `{"quotes": [], "expiration_date": "2099-12-17T00:00:00Z"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			res := httptest.NewRecorder()
+			router.ServeHTTP(res, req)
+			assert.Equal(t, tt.status, res.Code)
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			require.NoError(t, err)
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+			}
+			if tt.body != "" {
+				requestInput.Request.Body = io.NopCloser(strings.NewReader(tt.body))
+			}
+			assert.NoError(t, openapi3filter.ValidateRequest(ctx, requestInput))
+
+			assert.NoError(t, openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 res.Code,
+				Header:                 res.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(res.Body.Bytes())),
+			}))
+		})
+	}
+
+	t.Run("the document is served", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/openapi.yaml", nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, api.OpenAPI, res.Body.Bytes())
+	})
+}